@@ -4,14 +4,28 @@ import (
 	"context"
 	"errors"
 	"strings"
+	"sync"
 
 	"github.com/dgraph-io/badger/v4"
 
 	"github.com/sourcenetwork/corekv"
+	"github.com/sourcenetwork/corekv/internal/watch"
 )
 
 type bDB struct {
 	db *badger.DB
+
+	// watchLk is held for reading whilst changes are committed, and for writing whilst the
+	// watchers are changed, so that each commit is either published to a new watcher or
+	// completed before it was created.
+	watchLk  sync.RWMutex
+	watchers map[*watch.Watcher]struct{}
+	// publishLk serialises the commits made whilst there are watchers.
+	publishLk sync.Mutex
+
+	// closing is closed when the store is closed, ending all watches.
+	closing   chan struct{}
+	closeOnce sync.Once
 }
 
 func NewDatastore(path string, opts badger.Options) (corekv.Store, error) {
@@ -36,7 +50,9 @@ func NewDatastoreFrom(db *badger.DB) corekv.Store {
 
 func newDatastoreFrom(db *badger.DB) *bDB {
 	return &bDB{
-		db: db,
+		db:       db,
+		watchers: map[*watch.Watcher]struct{}{},
+		closing:  make(chan struct{}),
 	}
 }
 
@@ -83,12 +99,15 @@ func (b *bDB) Delete(ctx context.Context, key []byte) error {
 }
 
 func (b *bDB) Close() error {
+	b.closeOnce.Do(func() {
+		close(b.closing)
+	})
 	return b.db.Close()
 }
 
 func (b *bDB) Iterator(ctx context.Context, iterOpts corekv.IterOptions) corekv.Iterator {
 	txn := b.newTxn(true)
-	it := txn.iterator(iterOpts)
+	it := txn.iterator(ctx, iterOpts)
 
 	// closer for discarding implicit txn
 	// so that the txn is discarded when the
@@ -96,7 +115,7 @@ func (b *bDB) Iterator(ctx context.Context, iterOpts corekv.IterOptions) corekv.
 	it.withCloser(func() error {
 		return txn.Discard(ctx)
 	})
-	return it
+	return corekv.Paginate(it, iterOpts)
 
 }
 
@@ -105,11 +124,21 @@ func (b *bDB) NewTxn(readonly bool) corekv.Txn {
 }
 
 func (b *bDB) newTxn(readonly bool) *bTxn {
-	return &bTxn{b.db.NewTransaction(!readonly)}
+	return &bTxn{
+		t:  b.db.NewTransaction(!readonly),
+		db: b,
+	}
 }
 
 type bTxn struct {
-	t *badger.Txn
+	t  *badger.Txn
+	db *bDB
+
+	// The changes made via this transaction, by key, published to any watchers on commit.
+	//
+	// The keys and values reference the slices given by the caller, Badger requires that
+	// these are not modified until the transaction has ended.
+	changes map[string]corekv.Event
 }
 
 func (txn *bTxn) Get(ctx context.Context, key []byte) ([]byte, error) {
@@ -134,29 +163,52 @@ func (txn *bTxn) Has(ctx context.Context, key []byte) (bool, error) {
 }
 
 func (txn *bTxn) Iterator(ctx context.Context, iterOpts corekv.IterOptions) corekv.Iterator {
-	return txn.iterator(iterOpts)
+	return corekv.Paginate(txn.iterator(ctx, iterOpts), iterOpts)
 }
 
-func (txn *bTxn) iterator(iopts corekv.IterOptions) iteratorCloser {
-	if iopts.Prefix != nil {
-		return newPrefixIterator(txn, iopts.Prefix, iopts.Reverse, iopts.KeysOnly)
-	}
-	return newRangeIterator(txn, iopts.Start, iopts.End, iopts.Reverse, iopts.KeysOnly)
+func (txn *bTxn) iterator(ctx context.Context, iopts corekv.IterOptions) iteratorCloser {
+	return newIterator(ctx, txn, iopts)
 }
 
 func (txn *bTxn) Set(ctx context.Context, key []byte, value []byte) error {
 	err := txn.t.Set(key, value)
-	return badgerErrToKVErr(err)
+	if err != nil {
+		return badgerErrToKVErr(err)
+	}
+
+	txn.record(corekv.Event{Key: key, Value: value})
+	return nil
 }
 
 func (txn *bTxn) Delete(ctx context.Context, key []byte) error {
 	err := txn.t.Delete(key)
-	return badgerErrToKVErr(err)
+	if err != nil {
+		return badgerErrToKVErr(err)
+	}
+
+	txn.record(corekv.Event{Key: key, IsDeleted: true})
+	return nil
+}
+
+// record records the given change so that it may be published once committed.
+func (txn *bTxn) record(event corekv.Event) {
+	if txn.changes == nil {
+		txn.changes = map[string]corekv.Event{}
+	}
+	txn.changes[string(event.Key)] = event
 }
 
+// Commit commits the transaction.
+//
+// If the given context has been cancelled the transaction will be discarded instead, as
+// it would be had the commit failed.
 func (txn *bTxn) Commit(ctx context.Context) error {
-	err := txn.t.Commit()
-	return badgerErrToKVErr(err)
+	if err := ctx.Err(); err != nil {
+		txn.t.Discard()
+		return err
+	}
+
+	return txn.db.commit(txn)
 }
 
 func (txn *bTxn) Discard(ctx context.Context) error {
@@ -169,6 +221,8 @@ var badgerErrToKVErrMap = map[error]error{
 	badger.ErrKeyNotFound:  corekv.ErrNotFound,
 	badger.ErrDiscardedTxn: corekv.ErrDiscardedTxn,
 	badger.ErrDBClosed:     corekv.ErrDBClosed,
+	badger.ErrConflict:     corekv.ErrTxnConflict,
+	badger.ErrTxnTooBig:    corekv.ErrTxnTooBig,
 }
 
 func badgerErrToKVErr(err error) error {
@@ -189,6 +243,10 @@ func badgerErrToKVErr(err error) error {
 			mappedErr = corekv.ErrNotFound
 		case errors.Is(err, badger.ErrDBClosed):
 			mappedErr = corekv.ErrDBClosed
+		case errors.Is(err, badger.ErrConflict):
+			mappedErr = corekv.ErrTxnConflict
+		case errors.Is(err, badger.ErrTxnTooBig):
+			mappedErr = corekv.ErrTxnTooBig
 		// Annoyingly, badger's error wrapping seems to break `errors.Is`, so we have to
 		// check the string
 		case strings.Contains(err.Error(), badger.ErrDBClosed.Error()):
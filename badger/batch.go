@@ -0,0 +1,55 @@
+package badger
+
+import (
+	"context"
+
+	"github.com/sourcenetwork/corekv"
+)
+
+var _ corekv.Batchable = (*bDB)(nil)
+
+// Batch returns a new [corekv.Batch] built upon a single write-only Badger transaction, so
+// that all of its operations are applied atomically.
+//
+// Badger transactions have a maximum size, operations that would cause a batch to exceed it
+// will return [corekv.ErrTxnTooBig] without being collected.
+func (b *bDB) Batch(ctx context.Context) (corekv.Batch, error) {
+	return &bBatch{
+		txn: b.newTxn(false),
+	}, nil
+}
+
+type bBatch struct {
+	txn *bTxn
+	// done is true if the batch has been committed or discarded.
+	done bool
+}
+
+var _ corekv.Batch = (*bBatch)(nil)
+
+func (batch *bBatch) Set(ctx context.Context, key []byte, value []byte) error {
+	return batch.txn.Set(ctx, key, value)
+}
+
+func (batch *bBatch) Delete(ctx context.Context, key []byte) error {
+	return batch.txn.Delete(ctx, key)
+}
+
+func (batch *bBatch) Commit(ctx context.Context) error {
+	if batch.done {
+		return corekv.ErrDiscardedTxn
+	}
+	batch.done = true
+
+	// As no items are read via the transaction, the commit cannot conflict.
+	return batch.txn.Commit(ctx)
+}
+
+func (batch *bBatch) Discard(ctx context.Context) error {
+	if batch.done {
+		return nil
+	}
+	batch.done = true
+
+	return batch.txn.Discard(ctx)
+}
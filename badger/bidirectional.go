@@ -0,0 +1,109 @@
+package badger
+
+import (
+	"bytes"
+
+	"github.com/sourcenetwork/corekv"
+)
+
+var _ corekv.BidirectionalIterator = (*iterator)(nil)
+
+// Prev moves the iterator backwards by one item.
+//
+// Badger iterators may only move in a single direction, so this is done by flipping to an
+// iterator of the opposite direction and seeking past the current key, before flipping
+// back and re-seeking to the found key.
+func (it *iterator) Prev() (bool, error) {
+	if it.reset {
+		return false, nil
+	}
+
+	key := it.currentKey()
+
+	it.flip()
+	var hasPrev bool
+	var err error
+	if key == nil {
+		// The iterator has been exhausted, so the previous item is the last one.
+		hasPrev, err = it.restart()
+	} else {
+		hasPrev, err = it.Seek(key)
+		if err == nil && hasPrev && bytes.Equal(it.i.Item().Key(), key) {
+			hasPrev, err = it.Next()
+		}
+	}
+	var target []byte
+	if hasPrev {
+		target = it.Key()
+	}
+	it.flip()
+
+	if err != nil {
+		return false, err
+	}
+	if !hasPrev {
+		it.Reset()
+		return false, nil
+	}
+
+	return it.Seek(target)
+}
+
+func (it *iterator) Peek() ([]byte, bool, error) {
+	if it.reset {
+		hasNext, err := it.restart()
+		it.Reset()
+		if err != nil || !hasNext {
+			return nil, false, err
+		}
+		return it.Key(), true, nil
+	}
+
+	key := it.currentKey()
+	if key == nil {
+		return nil, false, nil
+	}
+
+	hasNext, err := it.Next()
+	if err != nil {
+		return nil, false, err
+	}
+	var next []byte
+	if hasNext {
+		next = it.Key()
+	}
+
+	_, err = it.Seek(key)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return next, hasNext, nil
+}
+
+// currentKey returns a copy of the key at the current iterator location, or nil if the
+// iterator has been exhausted.
+func (it *iterator) currentKey() []byte {
+	if !it.valid() {
+		return nil
+	}
+	return it.Key()
+}
+
+// flip reverses the direction of iteration, swapping the underlying badger iterator with
+// one of the opposite direction.
+func (it *iterator) flip() {
+	if it.back == nil {
+		opts := it.opts
+		opts.Reverse = !opts.Reverse
+		if opts.Reverse {
+			// Reverse iterators must not be given a prefix, see `newIterator`.
+			opts.Prefix = nil
+		}
+		it.back = it.txn.NewIterator(opts)
+	}
+
+	it.i, it.back = it.back, it.i
+	it.opts.Reverse = !it.opts.Reverse
+	it.reverse = !it.reverse
+}
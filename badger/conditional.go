@@ -0,0 +1,96 @@
+package badger
+
+import (
+	"bytes"
+	"context"
+	"errors"
+
+	"github.com/dgraph-io/badger/v4"
+
+	"github.com/sourcenetwork/corekv"
+)
+
+var _ corekv.ConditionalWriter = (*bDB)(nil)
+
+func (b *bDB) CompareAndSwap(ctx context.Context, key, expectedOld, newValue []byte) (bool, error) {
+	return b.writeIf(ctx, key, func(value []byte, exists bool) bool {
+		return exists && bytes.Equal(value, expectedOld)
+	}, func(txn *bTxn) error {
+		return txn.Set(ctx, key, newValue)
+	})
+}
+
+func (b *bDB) SetIfAbsent(ctx context.Context, key, value []byte) (bool, error) {
+	return b.writeIf(ctx, key, func(_ []byte, exists bool) bool {
+		return !exists
+	}, func(txn *bTxn) error {
+		return txn.Set(ctx, key, value)
+	})
+}
+
+func (b *bDB) DeleteIfEquals(ctx context.Context, key, expected []byte) (bool, error) {
+	return b.writeIf(ctx, key, func(value []byte, exists bool) bool {
+		return exists && bytes.Equal(value, expected)
+	}, func(txn *bTxn) error {
+		return txn.Delete(ctx, key)
+	})
+}
+
+// writeIf executes the given write within a new transaction if the given condition
+// is met by the current value of the given key.
+//
+// If the transaction fails to commit due to a conflict, the read, condition and write
+// will be retried until it succeeds or the given context is cancelled.
+func (b *bDB) writeIf(
+	ctx context.Context,
+	key []byte,
+	condition func(value []byte, exists bool) bool,
+	write func(*bTxn) error,
+) (bool, error) {
+	for {
+		err := ctx.Err()
+		if err != nil {
+			return false, err
+		}
+
+		written, err := b.tryWriteIf(ctx, key, condition, write)
+		if errors.Is(err, badger.ErrConflict) {
+			continue
+		}
+		return written, err
+	}
+}
+
+func (b *bDB) tryWriteIf(
+	ctx context.Context,
+	key []byte,
+	condition func(value []byte, exists bool) bool,
+	write func(*bTxn) error,
+) (bool, error) {
+	txn := b.newTxn(false)
+
+	value, err := txn.Get(ctx, key)
+	exists := true
+	if errors.Is(err, corekv.ErrNotFound) {
+		exists = false
+	} else if err != nil {
+		dErr := txn.Discard(ctx)
+		return false, errors.Join(err, dErr)
+	}
+
+	if !condition(value, exists) {
+		return false, txn.Discard(ctx)
+	}
+
+	err = write(txn)
+	if err != nil {
+		dErr := txn.Discard(ctx)
+		return false, errors.Join(err, dErr)
+	}
+
+	err = txn.Commit(ctx)
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
@@ -0,0 +1,70 @@
+package badger
+
+import (
+	"context"
+	"errors"
+
+	"github.com/sourcenetwork/corekv"
+)
+
+var _ corekv.RangeDeleter = (*bDB)(nil)
+
+// DeleteRange removes all the items within the given range using a single transaction.
+//
+// Large ranges may exceed Badger's maximum transaction size, in which case
+// [corekv.ErrTxnTooBig] will be returned and no items will be removed.
+func (b *bDB) DeleteRange(ctx context.Context, start, end []byte) error {
+	txn := b.newTxn(false)
+
+	err := txn.deleteRange(ctx, start, end)
+	if err != nil {
+		dErr := txn.Discard(ctx)
+		return errors.Join(err, dErr)
+	}
+
+	return txn.Commit(ctx)
+}
+
+// DeletePrefix removes all the items with the given prefix using a single transaction.
+//
+// Large prefixes may exceed Badger's maximum transaction size, in which case
+// [corekv.ErrTxnTooBig] will be returned and no items will be removed.
+func (b *bDB) DeletePrefix(ctx context.Context, prefix []byte) error {
+	if len(prefix) == 0 {
+		return corekv.ErrEmptyKey
+	}
+
+	return b.DeleteRange(ctx, prefix, corekv.PrefixEnd(prefix))
+}
+
+func (txn *bTxn) deleteRange(ctx context.Context, start, end []byte) error {
+	it := newIterator(ctx, txn, corekv.IterOptions{Start: start, End: end, KeysOnly: true})
+
+	// Badger does not support mutating a transaction whilst iterating through it,
+	// so we must collect the keys before deleting them.
+	keys := [][]byte{}
+	for {
+		hasValue, err := it.Next()
+		if err != nil {
+			return errors.Join(err, it.Close(ctx))
+		}
+		if !hasValue {
+			break
+		}
+		keys = append(keys, it.Key())
+	}
+
+	err := it.Close(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, key := range keys {
+		err := txn.Delete(ctx, key)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,72 @@
+package badger
+
+import (
+	"context"
+	"errors"
+
+	"github.com/dgraph-io/badger/v4"
+
+	"github.com/sourcenetwork/corekv"
+)
+
+var _ corekv.MultiReader = (*bDB)(nil)
+var _ corekv.MultiReader = (*bTxn)(nil)
+
+func (b *bDB) GetMany(ctx context.Context, keys [][]byte) ([]corekv.GetResult, error) {
+	txn := b.newTxn(true)
+
+	result, err := txn.GetMany(ctx, keys)
+	dErr := txn.Discard(ctx)
+
+	return result, errors.Join(err, dErr)
+}
+
+func (b *bDB) HasMany(ctx context.Context, keys [][]byte) ([]bool, error) {
+	txn := b.newTxn(true)
+
+	result, err := txn.HasMany(ctx, keys)
+	dErr := txn.Discard(ctx)
+
+	return result, errors.Join(err, dErr)
+}
+
+func (txn *bTxn) GetMany(ctx context.Context, keys [][]byte) ([]corekv.GetResult, error) {
+	results := make([]corekv.GetResult, len(keys))
+	for i, key := range keys {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		item, err := txn.t.Get(key)
+		if errors.Is(err, badger.ErrKeyNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, badgerErrToKVErr(err)
+		}
+
+		value, err := item.ValueCopy(nil)
+		if err != nil {
+			return nil, err
+		}
+		results[i] = corekv.GetResult{
+			Value: value,
+			Found: true,
+		}
+	}
+	return results, nil
+}
+
+func (txn *bTxn) HasMany(ctx context.Context, keys [][]byte) ([]bool, error) {
+	results := make([]bool, len(keys))
+	for i, key := range keys {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		has, err := txn.Has(ctx, key)
+		if err != nil {
+			return nil, err
+		}
+		results[i] = has
+	}
+	return results, nil
+}
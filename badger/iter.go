@@ -15,7 +15,22 @@ type iteratorCloser interface {
 }
 
 type iterator struct {
-	i        *badger.Iterator
+	i *badger.Iterator
+
+	// The context the iterator was created with, moving the iterator will fail once
+	// it is cancelled.
+	ctx context.Context
+
+	// The transaction and options from which `i` was created, used to lazily create `back`.
+	txn  *badger.Txn
+	opts badger.IteratorOptions
+
+	// back iterates in the opposite direction to `i`, and is used to move the iterator
+	// backwards.
+	//
+	// It is created on first use, and swapped with `i` whilst moving backwards.
+	back *badger.Iterator
+
 	start    []byte
 	end      []byte
 	reverse  bool
@@ -24,36 +39,36 @@ type iterator struct {
 	// returned to the beginning on the next [Next] call.
 	reset  bool
 	closer func() error
-}
-
-func newPrefixIterator(txn *bTxn, prefix []byte, reverse, keysOnly bool) *iterator {
-	opt := badger.DefaultIteratorOptions
-	opt.Reverse = reverse
-	opt.Prefix = prefix
-	opt.PrefetchValues = !keysOnly
 
-	return &iterator{
-		i:        txn.t.NewIterator(opt),
-		start:    prefix,
-		end:      bytesPrefixEnd(prefix),
-		reverse:  reverse,
-		keysOnly: keysOnly,
-		reset:    true,
-	}
+	// err is set if the iterator was created with invalid options, and will be
+	// returned on any attempt to move the iterator.
+	err error
 }
 
-func newRangeIterator(txn *bTxn, start, end []byte, reverse, keysOnky bool) *iterator {
+func newIterator(ctx context.Context, txn *bTxn, iopts corekv.IterOptions) *iterator {
+	start, end, err := iopts.Bounds()
+
 	opt := badger.DefaultIteratorOptions
-	opt.Reverse = reverse
-	opt.PrefetchValues = !keysOnky
+	opt.Reverse = iopts.Reverse
+	if !iopts.Reverse {
+		// Badger will reject the key landed on by a reverse seek to the end of the prefix if
+		// that key exists, ending the iteration, so the prefix may only be provided to
+		// forward iterators.  Reverse iterators are kept within the prefix by `valid`.
+		opt.Prefix = iopts.Prefix
+	}
+	opt.PrefetchValues = !iopts.KeysOnly
 
 	return &iterator{
 		i:        txn.t.NewIterator(opt),
+		ctx:      ctx,
+		txn:      txn.t,
+		opts:     opt,
 		start:    start,
 		end:      end,
-		reverse:  reverse,
-		keysOnly: keysOnky,
+		reverse:  iopts.Reverse,
+		keysOnly: iopts.KeysOnly,
 		reset:    true,
+		err:      err,
 	}
 }
 
@@ -90,6 +105,12 @@ func (it *iterator) valid() bool {
 }
 
 func (it *iterator) Next() (bool, error) {
+	if it.err != nil {
+		return false, it.err
+	}
+	if err := it.ctx.Err(); err != nil {
+		return false, err
+	}
 	if it.reset {
 		return it.restart()
 	}
@@ -117,6 +138,13 @@ func (it *iterator) Value() ([]byte, error) {
 }
 
 func (it *iterator) Seek(key []byte) (bool, error) {
+	if it.err != nil {
+		return false, it.err
+	}
+	if err := it.ctx.Err(); err != nil {
+		return false, err
+	}
+
 	// Clear the reset property, else if Next was call following Seek,
 	// Next may incorrectly return to the beginning.
 	it.reset = false
@@ -151,6 +179,9 @@ func (it *iterator) Seek(key []byte) (bool, error) {
 
 func (it *iterator) Close(ctx context.Context) error {
 	it.i.Close()
+	if it.back != nil {
+		it.back.Close()
+	}
 	if it.closer != nil {
 		return it.closer()
 	}
@@ -161,20 +192,6 @@ func (it *iterator) withCloser(closer func() error) {
 	it.closer = closer
 }
 
-func bytesPrefixEnd(b []byte) []byte {
-	end := make([]byte, len(b))
-	copy(end, b)
-	for i := len(end) - 1; i >= 0; i-- {
-		end[i] = end[i] + 1
-		if end[i] != 0 {
-			return end[:i+1]
-		}
-	}
-	// This statement will only be reached if the key is already a
-	// maximal byte string (i.e. already \xff...).
-	return b
-}
-
 // less than (a < b)
 func lt(a, b []byte) bool {
 	return bytes.Compare(a, b) == -1
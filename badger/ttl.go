@@ -0,0 +1,36 @@
+package badger
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/dgraph-io/badger/v4"
+
+	"github.com/sourcenetwork/corekv"
+)
+
+var _ corekv.TTLWriter = (*bDB)(nil)
+var _ corekv.TTLWriter = (*bTxn)(nil)
+
+func (b *bDB) SetWithTTL(ctx context.Context, key, value []byte, ttl time.Duration) error {
+	txn := b.newTxn(false)
+
+	err := txn.SetWithTTL(ctx, key, value, ttl)
+	if err != nil {
+		dErr := txn.Discard(ctx)
+		return errors.Join(err, dErr)
+	}
+
+	return txn.Commit(ctx)
+}
+
+func (txn *bTxn) SetWithTTL(ctx context.Context, key, value []byte, ttl time.Duration) error {
+	err := txn.t.SetEntry(badger.NewEntry(key, value).WithTTL(ttl))
+	if err != nil {
+		return badgerErrToKVErr(err)
+	}
+
+	txn.record(corekv.Event{Key: key, Value: value})
+	return nil
+}
@@ -0,0 +1,88 @@
+package badger
+
+import (
+	"bytes"
+	"context"
+	"sort"
+
+	"github.com/sourcenetwork/corekv"
+	"github.com/sourcenetwork/corekv/internal/watch"
+)
+
+var _ corekv.Watchable = (*bDB)(nil)
+
+// Watch returns a channel yielding the changes committed to items with the given prefix.
+//
+// Changes are published by corekv as they are committed, so the watch is registered before
+// Watch returns and all changes committed afterwards will be yielded.  Changes written
+// directly to the underlying Badger database, and not via corekv, are not yielded.
+//
+// The events of up to [watch.DefaultQueueSize] commits are queued for delivery, commits are
+// never blocked by slow watchers.  If the queue is full when a change is committed, the
+// change is dropped and, once the queued events have been delivered, an event holding
+// [corekv.ErrWatchOverflow] is yielded before the channel is closed.
+func (b *bDB) Watch(ctx context.Context, prefix []byte) (<-chan corekv.Event, error) {
+	if b.db.IsClosed() {
+		return nil, corekv.ErrDBClosed
+	}
+
+	w := watch.New(prefix, watch.DefaultQueueSize)
+
+	// Taking the write lock waits for any in-progress commits, which the watcher may or may
+	// not otherwise observe, to complete.
+	b.watchLk.Lock()
+	b.watchers[w] = struct{}{}
+	b.watchLk.Unlock()
+
+	go func() {
+		w.Deliver(ctx, b.closing)
+
+		b.watchLk.Lock()
+		delete(b.watchers, w)
+		b.watchLk.Unlock()
+	}()
+
+	return w.Events(), nil
+}
+
+// commit commits the given transaction, publishing its changes to any watchers.
+func (b *bDB) commit(txn *bTxn) error {
+	b.watchLk.RLock()
+	defer b.watchLk.RUnlock()
+
+	if len(b.watchers) == 0 || len(txn.changes) == 0 {
+		return badgerErrToKVErr(txn.t.Commit())
+	}
+
+	// Changes must be published in commit order, so commits are serialised whilst there are
+	// watchers to publish them to.
+	b.publishLk.Lock()
+	defer b.publishLk.Unlock()
+
+	err := txn.t.Commit()
+	if err != nil {
+		return badgerErrToKVErr(err)
+	}
+
+	events := make([]corekv.Event, 0, len(txn.changes))
+	for _, event := range txn.changes {
+		// The caller may reuse the given slices once the commit has returned, so the
+		// delivered events must hold copies.
+		event.Key = bytes.Clone(event.Key)
+		if !event.IsDeleted {
+			event.Value = bytes.Clone(event.Value)
+		}
+		events = append(events, event)
+	}
+	// Changes committed together are published in lexographical order of their keys, to
+	// match the ordering of other stores.
+	sort.Slice(events, func(i, j int) bool {
+		return bytes.Compare(events[i].Key, events[j].Key) < 0
+	})
+
+	for w := range b.watchers {
+		w.Publish(events)
+	}
+
+	return nil
+}
@@ -0,0 +1,88 @@
+package corekv
+
+import "bytes"
+
+// Bounds returns the range of keys described by these options, as an inclusive start key and
+// an exclusive end key.
+//
+// The Prefix, Start, End and ResumeToken options, and the bound kinds, are combined into
+// this single range.  A nil start or end denotes an unbounded side of the range.  If no keys may fall
+// within the options the returned start will be greater than or equal to the returned end.
+//
+// An [*InvalidRangeError] will be returned if Start is greater than End, and
+// [ErrInvalidResumeToken] if the ResumeToken cannot be used with these options.
+func (opts IterOptions) Bounds() (start []byte, end []byte, err error) {
+	if opts.Start != nil && opts.End != nil && bytes.Compare(opts.Start, opts.End) > 0 {
+		return nil, nil, &InvalidRangeError{
+			Start: opts.Start,
+			End:   opts.End,
+		}
+	}
+
+	start = opts.Start
+	if start != nil && opts.StartExclusive {
+		start = successor(start)
+	}
+
+	end = opts.End
+	if end != nil && opts.EndInclusive {
+		end = successor(end)
+	}
+
+	if len(opts.Prefix) > 0 {
+		if start == nil || bytes.Compare(start, opts.Prefix) < 0 {
+			start = opts.Prefix
+		}
+
+		pEnd := PrefixEnd(opts.Prefix)
+		if pEnd != nil && (end == nil || bytes.Compare(pEnd, end) < 0) {
+			end = pEnd
+		}
+	}
+
+	if opts.ResumeToken != nil {
+		key, reverse, err := ParseResumeToken(opts.ResumeToken)
+		if err != nil {
+			return nil, nil, err
+		}
+		if reverse != opts.Reverse {
+			return nil, nil, ErrInvalidResumeToken
+		}
+
+		if reverse {
+			if end == nil || bytes.Compare(key, end) < 0 {
+				end = key
+			}
+		} else {
+			next := successor(key)
+			if start == nil || bytes.Compare(start, next) < 0 {
+				start = next
+			}
+		}
+	}
+
+	return start, end, nil
+}
+
+// successor returns the smallest key greater than the given key.
+func successor(key []byte) []byte {
+	result := make([]byte, len(key)+1)
+	copy(result, key)
+	return result
+}
+
+// PrefixEnd returns the smallest key greater than all keys beginning with the given prefix.
+//
+// If no such key exists, nil is returned.
+func PrefixEnd(prefix []byte) []byte {
+	end := make([]byte, len(prefix))
+	copy(end, prefix)
+	for i := len(end) - 1; i >= 0; i-- {
+		end[i] = end[i] + 1
+		if end[i] != 0 {
+			return end[:i+1]
+		}
+	}
+	// The prefix is a maximal byte string (i.e. \xff...), so no key beyond it exists.
+	return nil
+}
@@ -0,0 +1,78 @@
+package cache
+
+import (
+	"context"
+
+	"github.com/sourcenetwork/corekv"
+)
+
+var _ corekv.Batchable = (*cacheStore)(nil)
+
+// Batch returns a new batch of the underlying store, once the batch has been committed all
+// the keys written by it will be invalidated.
+//
+// If the underlying store does not support batches [corekv.ErrNotSupported] will be returned.
+func (s *cacheStore) Batch(ctx context.Context) (corekv.Batch, error) {
+	store, ok := s.store.(corekv.Batchable)
+	if !ok {
+		return nil, corekv.ErrNotSupported
+	}
+
+	batch, err := store.Batch(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &cacheBatch{
+		Batch: batch,
+		store: s,
+	}, nil
+}
+
+type cacheBatch struct {
+	corekv.Batch
+	store *cacheStore
+
+	// The keys written to by this batch.
+	keys [][]byte
+}
+
+var _ corekv.Batch = (*cacheBatch)(nil)
+
+func (b *cacheBatch) Set(ctx context.Context, key []byte, value []byte) error {
+	err := b.Batch.Set(ctx, key, value)
+	if err != nil {
+		return err
+	}
+
+	b.keys = append(b.keys, copyBytes(key))
+	return nil
+}
+
+func (b *cacheBatch) Delete(ctx context.Context, key []byte) error {
+	err := b.Batch.Delete(ctx, key)
+	if err != nil {
+		return err
+	}
+
+	b.keys = append(b.keys, copyBytes(key))
+	return nil
+}
+
+func (b *cacheBatch) Commit(ctx context.Context) error {
+	err := b.Batch.Commit(ctx)
+
+	// The keys are invalidated even if the commit fails, as it may have been partially
+	// applied by the underlying store.
+	if len(b.keys) > 0 {
+		b.store.written(err == nil, b.keys, nil)
+		b.keys = nil
+	}
+
+	return err
+}
+
+func (b *cacheBatch) Discard(ctx context.Context) error {
+	b.keys = nil
+	return b.Batch.Discard(ctx)
+}
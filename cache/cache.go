@@ -0,0 +1,354 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/sourcenetwork/corekv"
+)
+
+// Cache is a [corekv.Store] that caches the results of reads from an underlying store.
+type Cache interface {
+	corekv.Store
+
+	// Stats returns the current statistics of the cache.
+	Stats() Stats
+}
+
+// Stats holds statistics about the use of a [Cache].
+type Stats struct {
+	// The number of reads answered by the cache.
+	Hits uint64
+
+	// The number of reads that had to be made against the underlying store.
+	Misses uint64
+
+	// The number of entries removed from the cache in order to stay within its maximum cost.
+	Evictions uint64
+
+	// The number of entries currently held in the cache.
+	Entries int
+
+	// The approximate size, in bytes, of the entries currently held in the cache.
+	Cost int64
+}
+
+// cacheStore caches the results of `Get` and `Has` calls made against the underlying store,
+// including whether or not keys exist.
+type cacheStore struct {
+	store corekv.Store
+	opts  options
+
+	// lk guards all the fields below it.
+	lk  sync.Mutex
+	lru *lru
+
+	// The reads of the underlying store that are in progress, by key.
+	fills map[string][]*fill
+
+	// The expiry times of the keys written with a time-to-live via this store, these keys are
+	// not cached until they have expired.
+	expiries map[string]time.Time
+	// The number of expiries at which expired keys will next be removed from expiries.
+	nextExpirySweep int
+
+	hits   uint64
+	misses uint64
+}
+
+var _ Cache = (*cacheStore)(nil)
+
+// fill is a read of a key from the underlying store, the result of which will be cached.
+type fill struct {
+	// The key being read.
+	key string
+
+	// stale is set if the key is invalidated whilst the read is in progress, in which case
+	// the result will not be cached.
+	//
+	// This prevents a read that races with a write from caching a stale value.
+	stale bool
+}
+
+// Wrap returns a [Cache] that caches reads from the given store, holding entries up to a
+// total approximate size of maxCost bytes, evicting the least recently used entries once
+// that is exceeded.
+//
+// Cached entries are invalidated by writes made through the returned store, including
+// those made by committed transactions.  Writes made directly to the given store, or through
+// any other wrapper, will not invalidate the cache.
+//
+// If the given store is a [corekv.TxnStore] the returned store will also be a
+// [corekv.TxnStore].  The returned store implements all the other optional interfaces, such
+// as [corekv.Batchable], returning [corekv.ErrNotSupported] from any that are not implemented
+// by the given store.  Writes made via them invalidate the cache, reads made via them, such as
+// [corekv.MultiReader.GetMany], do not make use of it.
+//
+// Keys written with a time-to-live via the returned store are not cached until they have
+// expired, keys written with a time-to-live directly to the given store must not be read via
+// the returned store.
+func Wrap(store corekv.Store, maxCost int64, opts ...Option) Cache {
+	cstore := &cacheStore{
+		store:           store,
+		opts:            newOptions(opts),
+		lru:             newLRU(maxCost),
+		fills:           map[string][]*fill{},
+		expiries:        map[string]time.Time{},
+		nextExpirySweep: minExpirySweep,
+	}
+
+	if _, ok := store.(corekv.TxnStore); ok {
+		return &cacheTxnStore{cstore}
+	}
+
+	return cstore
+}
+
+func (s *cacheStore) Get(ctx context.Context, key []byte) ([]byte, error) {
+	e, f, ok := s.lookup(key, true)
+	if ok {
+		if !e.found {
+			return nil, corekv.ErrNotFound
+		}
+		return copyBytes(e.value), nil
+	}
+
+	value, err := s.store.Get(ctx, key)
+	switch {
+	case err == nil:
+		s.add(f, &entry{
+			key:      string(key),
+			found:    true,
+			hasValue: true,
+			value:    copyBytes(value),
+		})
+
+	case errors.Is(err, corekv.ErrNotFound):
+		s.add(f, &entry{
+			key: string(key),
+		})
+
+	default:
+		s.add(f, nil)
+	}
+
+	return value, err
+}
+
+func (s *cacheStore) Has(ctx context.Context, key []byte) (bool, error) {
+	e, f, ok := s.lookup(key, false)
+	if ok {
+		return e.found, nil
+	}
+
+	has, err := s.store.Has(ctx, key)
+	if err != nil {
+		s.add(f, nil)
+		return false, err
+	}
+
+	s.add(f, &entry{
+		key:   string(key),
+		found: has,
+	})
+
+	return has, nil
+}
+
+func (s *cacheStore) Set(ctx context.Context, key []byte, value []byte) error {
+	err := s.store.Set(ctx, key, value)
+	s.written(err == nil, [][]byte{key}, nil)
+	return err
+}
+
+func (s *cacheStore) Delete(ctx context.Context, key []byte) error {
+	err := s.store.Delete(ctx, key)
+	s.written(err == nil, [][]byte{key}, nil)
+	return err
+}
+
+// Iterator returns an iterator over the underlying store, iterators do not make use of the
+// cache.
+func (s *cacheStore) Iterator(ctx context.Context, opts corekv.IterOptions) corekv.Iterator {
+	return s.store.Iterator(ctx, opts)
+}
+
+// Close clears the cache and closes the underlying store.
+func (s *cacheStore) Close() error {
+	s.lk.Lock()
+	s.lru.clear()
+	for _, fills := range s.fills {
+		for _, f := range fills {
+			f.stale = true
+		}
+	}
+	s.lk.Unlock()
+
+	return s.store.Close()
+}
+
+func (s *cacheStore) Stats() Stats {
+	s.lk.Lock()
+	defer s.lk.Unlock()
+
+	return Stats{
+		Hits:      s.hits,
+		Misses:    s.misses,
+		Evictions: s.lru.evictions,
+		Entries:   len(s.lru.entries),
+		Cost:      s.lru.cost,
+	}
+}
+
+// lookup returns the entry cached against the given key, if there is one.
+//
+// If no entry is returned, a new fill of the key is started and returned, which must be
+// completed by calling [cacheStore.add].
+//
+// If needValue is true, entries that do not hold the value of an existing key will not be
+// returned.  A hit or miss will be recorded.
+func (s *cacheStore) lookup(key []byte, needValue bool) (*entry, *fill, bool) {
+	s.lk.Lock()
+	defer s.lk.Unlock()
+
+	e, ok := s.lru.get(string(key))
+	if ok && needValue && e.found && !e.hasValue {
+		ok = false
+	}
+	if ok {
+		s.hits++
+		return e, nil, true
+	}
+
+	s.misses++
+	f := &fill{key: string(key)}
+	s.fills[string(key)] = append(s.fills[string(key)], f)
+	return nil, f, false
+}
+
+// add completes the given fill, caching the given entry providing that its key has not been
+// invalidated since the fill started, and that the key has no unexpired time-to-live.
+//
+// If the given entry is nil the fill is completed without caching anything.
+func (s *cacheStore) add(f *fill, e *entry) {
+	s.lk.Lock()
+	defer s.lk.Unlock()
+
+	fills := s.fills[f.key]
+	for i := range fills {
+		if fills[i] == f {
+			fills = append(fills[:i], fills[i+1:]...)
+			break
+		}
+	}
+	if len(fills) == 0 {
+		delete(s.fills, f.key)
+	} else {
+		s.fills[f.key] = fills
+	}
+
+	if e == nil || f.stale {
+		return
+	}
+	if expiresAt, ok := s.expiries[e.key]; ok {
+		if s.opts.clock().Before(expiresAt) {
+			return
+		}
+		delete(s.expiries, e.key)
+	}
+
+	s.lru.add(e)
+}
+
+// written invalidates the given keys following an attempt to write them, preventing the
+// results of any reads of them that are in progress from being cached.
+//
+// The given time-to-lives are those of the keys that were written with one, the expiries of
+// these keys are recorded so that they are not cached until they have expired.  If the write
+// succeeded, the recorded expiries of the other keys are removed.
+func (s *cacheStore) written(succeeded bool, keys [][]byte, ttls map[string]time.Duration) {
+	s.lk.Lock()
+	defer s.lk.Unlock()
+
+	now := s.opts.clock()
+	for _, key := range keys {
+		k := string(key)
+		s.invalidateLocked(k)
+
+		ttl, hasTTL := ttls[k]
+		switch {
+		case hasTTL:
+			// If the write failed it may still have been partially applied, so the key must
+			// not be cached before either expiry.
+			expiresAt := now.Add(ttl)
+			if succeeded || expiresAt.After(s.expiries[k]) {
+				s.expiries[k] = expiresAt
+			}
+		case succeeded:
+			delete(s.expiries, k)
+		}
+	}
+
+	if len(ttls) > 0 && len(s.expiries) >= s.nextExpirySweep {
+		// Expired keys are only otherwise removed when read, so they are swept here to stop
+		// expiries growing without bound.
+		for k, expiresAt := range s.expiries {
+			if !now.Before(expiresAt) {
+				delete(s.expiries, k)
+			}
+		}
+		s.nextExpirySweep = 2 * len(s.expiries)
+		if s.nextExpirySweep < minExpirySweep {
+			s.nextExpirySweep = minExpirySweep
+		}
+	}
+}
+
+// invalidateRange removes the keys within the given range from the cache, preventing the
+// results of any reads of them that are in progress from being cached.
+//
+// A nil start or end leaves the range unbounded in that direction.  This visits every
+// entry in the cache.
+func (s *cacheStore) invalidateRange(start, end []byte) {
+	s.lk.Lock()
+	defer s.lk.Unlock()
+
+	inRange := func(key string) bool {
+		return (start == nil || key >= string(start)) && (end == nil || key < string(end))
+	}
+
+	for key := range s.lru.entries {
+		if inRange(key) {
+			s.lru.remove(key)
+		}
+	}
+	for key, fills := range s.fills {
+		if inRange(key) {
+			for _, f := range fills {
+				f.stale = true
+			}
+		}
+	}
+}
+
+// invalidateLocked removes the given key from the cache, preventing the results of any reads
+// of it that are in progress from being cached.
+//
+// The lk must be held when calling this function.
+func (s *cacheStore) invalidateLocked(key string) {
+	s.lru.remove(key)
+	for _, f := range s.fills[key] {
+		f.stale = true
+	}
+}
+
+func copyBytes(b []byte) []byte {
+	if b == nil {
+		return nil
+	}
+	result := make([]byte, len(b))
+	copy(result, b)
+	return result
+}
@@ -0,0 +1,139 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sourcenetwork/corekv"
+	"github.com/sourcenetwork/corekv/memory"
+	"github.com/sourcenetwork/corekv/test/state"
+	"github.com/stretchr/testify/require"
+)
+
+// blockingStore blocks `Get` calls until unblock is closed, after signalling on reading.
+type blockingStore struct {
+	corekv.Store
+	reading chan struct{}
+	unblock chan struct{}
+}
+
+func (s *blockingStore) Get(ctx context.Context, key []byte) ([]byte, error) {
+	value, err := s.Store.Get(ctx, key)
+	close(s.reading)
+	<-s.unblock
+	return value, err
+}
+
+// getDuringWrite reads k1 via a cache, writing the given key whilst the read of the
+// underlying store is in progress, and returns the cache.
+func getDuringWrite(t *testing.T, key []byte) Cache {
+	ctx := context.Background()
+	store := memory.NewDatastore(ctx)
+	t.Cleanup(func() { store.Close() })
+
+	err := store.Set(ctx, []byte("k1"), []byte("v1"))
+	require.NoError(t, err)
+
+	bstore := &blockingStore{
+		Store:   store,
+		reading: make(chan struct{}),
+		unblock: make(chan struct{}),
+	}
+	c := Wrap(bstore, 1024)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_, err := c.Get(ctx, []byte("k1"))
+		require.NoError(t, err)
+	}()
+
+	<-bstore.reading
+	err = c.Set(ctx, key, []byte("v2"))
+	require.NoError(t, err)
+	close(bstore.unblock)
+	<-done
+
+	return c
+}
+
+func TestGet_WriteToOtherKeyDuringRead_Caches(t *testing.T) {
+	c := getDuringWrite(t, []byte("k2"))
+	require.Equal(t, 1, c.Stats().Entries)
+}
+
+func TestGet_WriteToSameKeyDuringRead_DoesNotCache(t *testing.T) {
+	c := getDuringWrite(t, []byte("k1"))
+	require.Equal(t, 0, c.Stats().Entries)
+}
+
+// plainStore hides all optional interfaces implemented by the wrapped store.
+type plainStore struct {
+	corekv.Store
+}
+
+func TestWrap_TxnStore(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewDatastore(ctx)
+	defer store.Close()
+
+	_, ok := Wrap(store, 1024).(corekv.TxnStore)
+	require.True(t, ok)
+
+	_, ok = Wrap(&plainStore{store}, 1024).(corekv.TxnStore)
+	require.False(t, ok)
+}
+
+func TestWrap_NoCapabilities_ReturnsNotSupported(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewDatastore(ctx)
+	defer store.Close()
+
+	c := Wrap(&plainStore{store}, 1024)
+	key := []byte("k1")
+
+	_, err := c.(corekv.Batchable).Batch(ctx)
+	require.ErrorIs(t, err, corekv.ErrNotSupported)
+
+	_, err = c.(corekv.ConditionalWriter).SetIfAbsent(ctx, key, []byte("v1"))
+	require.ErrorIs(t, err, corekv.ErrNotSupported)
+
+	err = c.(corekv.RangeDeleter).DeletePrefix(ctx, key)
+	require.ErrorIs(t, err, corekv.ErrNotSupported)
+
+	_, err = c.(corekv.Watchable).Watch(ctx, key)
+	require.ErrorIs(t, err, corekv.ErrNotSupported)
+
+	_, err = c.(corekv.MultiReader).GetMany(ctx, [][]byte{key})
+	require.ErrorIs(t, err, corekv.ErrNotSupported)
+
+	err = c.(corekv.TTLWriter).SetWithTTL(ctx, key, []byte("v1"), 0)
+	require.ErrorIs(t, err, corekv.ErrNotSupported)
+}
+
+func TestSetWithTTL_WithClock_CachesOnceExpired(t *testing.T) {
+	ctx := context.Background()
+	clock := state.NewClock(time.Now())
+
+	store := memory.NewDatastore(ctx, memory.WithClock(clock.Now))
+	defer store.Close()
+	c := Wrap(store, 1024, WithClock(clock.Now))
+
+	err := c.(corekv.TTLWriter).SetWithTTL(ctx, []byte("k1"), []byte("v1"), time.Minute)
+	require.NoError(t, err)
+
+	_, err = c.Get(ctx, []byte("k1"))
+	require.NoError(t, err)
+	require.Equal(t, 0, c.Stats().Entries)
+
+	clock.Advance(time.Minute)
+
+	_, err = c.Get(ctx, []byte("k1"))
+	require.ErrorIs(t, err, corekv.ErrNotFound)
+	require.Equal(t, 1, c.Stats().Entries)
+
+	_, err = c.Get(ctx, []byte("k1"))
+	require.ErrorIs(t, err, corekv.ErrNotFound)
+	require.Equal(t, uint64(1), c.Stats().Hits)
+}
@@ -0,0 +1,57 @@
+package cache
+
+import (
+	"context"
+
+	"github.com/sourcenetwork/corekv"
+)
+
+var _ corekv.ConditionalWriter = (*cacheStore)(nil)
+
+// CompareAndSwap sets the value stored against the given key in the underlying store, if the
+// currently stored value is equal to expectedOld.
+//
+// If the underlying store does not support conditional writes [corekv.ErrNotSupported] will
+// be returned.
+func (s *cacheStore) CompareAndSwap(ctx context.Context, key, expectedOld, newValue []byte) (bool, error) {
+	store, ok := s.store.(corekv.ConditionalWriter)
+	if !ok {
+		return false, corekv.ErrNotSupported
+	}
+
+	swapped, err := store.CompareAndSwap(ctx, key, expectedOld, newValue)
+	s.written(err == nil && swapped, [][]byte{key}, nil)
+	return swapped, err
+}
+
+// SetIfAbsent sets the value stored against the given key in the underlying store, if no item
+// currently exists at that key.
+//
+// If the underlying store does not support conditional writes [corekv.ErrNotSupported] will
+// be returned.
+func (s *cacheStore) SetIfAbsent(ctx context.Context, key, value []byte) (bool, error) {
+	store, ok := s.store.(corekv.ConditionalWriter)
+	if !ok {
+		return false, corekv.ErrNotSupported
+	}
+
+	set, err := store.SetIfAbsent(ctx, key, value)
+	s.written(err == nil && set, [][]byte{key}, nil)
+	return set, err
+}
+
+// DeleteIfEquals removes the item at the given key from the underlying store, if the
+// currently stored value is equal to expected.
+//
+// If the underlying store does not support conditional writes [corekv.ErrNotSupported] will
+// be returned.
+func (s *cacheStore) DeleteIfEquals(ctx context.Context, key, expected []byte) (bool, error) {
+	store, ok := s.store.(corekv.ConditionalWriter)
+	if !ok {
+		return false, corekv.ErrNotSupported
+	}
+
+	deleted, err := store.DeleteIfEquals(ctx, key, expected)
+	s.written(err == nil && deleted, [][]byte{key}, nil)
+	return deleted, err
+}
@@ -0,0 +1,43 @@
+package cache
+
+import (
+	"context"
+
+	"github.com/sourcenetwork/corekv"
+)
+
+var _ corekv.RangeDeleter = (*cacheStore)(nil)
+
+// DeleteRange removes all the items within the given range from the underlying store,
+// invalidating all the cached keys within it.
+//
+// If the underlying store does not support range deletion [corekv.ErrNotSupported] will be
+// returned.
+func (s *cacheStore) DeleteRange(ctx context.Context, start, end []byte) error {
+	store, ok := s.store.(corekv.RangeDeleter)
+	if !ok {
+		return corekv.ErrNotSupported
+	}
+
+	err := store.DeleteRange(ctx, start, end)
+	s.invalidateRange(start, end)
+	return err
+}
+
+// DeletePrefix removes all the items with the given prefix from the underlying store,
+// invalidating all the cached keys with it.
+//
+// If the underlying store does not support range deletion [corekv.ErrNotSupported] will be
+// returned.
+func (s *cacheStore) DeletePrefix(ctx context.Context, prefix []byte) error {
+	store, ok := s.store.(corekv.RangeDeleter)
+	if !ok {
+		return corekv.ErrNotSupported
+	}
+
+	err := store.DeletePrefix(ctx, prefix)
+	if len(prefix) > 0 {
+		s.invalidateRange(prefix, corekv.PrefixEnd(prefix))
+	}
+	return err
+}
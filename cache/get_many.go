@@ -0,0 +1,37 @@
+package cache
+
+import (
+	"context"
+
+	"github.com/sourcenetwork/corekv"
+)
+
+var _ corekv.MultiReader = (*cacheStore)(nil)
+
+// GetMany returns the values at the given keys from the underlying store, without making use
+// of the cache.
+//
+// If the underlying store does not support reading many items [corekv.ErrNotSupported] will
+// be returned.
+func (s *cacheStore) GetMany(ctx context.Context, keys [][]byte) ([]corekv.GetResult, error) {
+	store, ok := s.store.(corekv.MultiReader)
+	if !ok {
+		return nil, corekv.ErrNotSupported
+	}
+
+	return store.GetMany(ctx, keys)
+}
+
+// HasMany returns true for each of the given keys at which an item is found in the underlying
+// store, without making use of the cache.
+//
+// If the underlying store does not support reading many items [corekv.ErrNotSupported] will
+// be returned.
+func (s *cacheStore) HasMany(ctx context.Context, keys [][]byte) ([]bool, error) {
+	store, ok := s.store.(corekv.MultiReader)
+	if !ok {
+		return nil, corekv.ErrNotSupported
+	}
+
+	return store.HasMany(ctx, keys)
+}
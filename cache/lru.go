@@ -0,0 +1,98 @@
+package cache
+
+import (
+	"container/list"
+)
+
+// entryOverhead is the approximate cost, in bytes, of holding an entry in the cache
+// in addition to its key and value.
+const entryOverhead = 64
+
+// entry is an item held in the cache.
+type entry struct {
+	key string
+
+	// found is false if the key is known not to exist in the store.
+	found bool
+
+	// hasValue is true if value holds the value of the key, it will be false if only
+	// the existence of the key is known.
+	hasValue bool
+	value    []byte
+}
+
+func (e *entry) cost() int64 {
+	return int64(len(e.key) + len(e.value) + entryOverhead)
+}
+
+// lru is a least-recently-used cache bounded by the total cost of its entries.
+//
+// It is not safe for concurrent use.
+type lru struct {
+	maxCost int64
+	cost    int64
+
+	// Entries ordered from most to least recently used.
+	order   *list.List
+	entries map[string]*list.Element
+
+	evictions uint64
+}
+
+func newLRU(maxCost int64) *lru {
+	return &lru{
+		maxCost: maxCost,
+		order:   list.New(),
+		entries: map[string]*list.Element{},
+	}
+}
+
+// get returns the entry held against the given key, marking it as recently used.
+func (c *lru) get(key string) (*entry, bool) {
+	elem, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+
+	c.order.MoveToFront(elem)
+	return elem.Value.(*entry), true
+}
+
+// add adds the given entry to the cache, replacing any existing entry for its key and
+// evicting the least recently used entries until the cache is within its maximum cost.
+func (c *lru) add(e *entry) {
+	c.remove(e.key)
+
+	if e.cost() > c.maxCost {
+		// The entry would evict everything else and still not fit.
+		return
+	}
+
+	c.entries[e.key] = c.order.PushFront(e)
+	c.cost += e.cost()
+
+	for c.cost > c.maxCost {
+		oldest := c.order.Back()
+		c.remove(oldest.Value.(*entry).key)
+		c.evictions++
+	}
+}
+
+// remove removes the entry held against the given key, if there is one.
+func (c *lru) remove(key string) {
+	elem, ok := c.entries[key]
+	if !ok {
+		return
+	}
+
+	c.order.Remove(elem)
+	delete(c.entries, key)
+	c.cost -= elem.Value.(*entry).cost()
+}
+
+// clear removes all entries from the cache.
+func (c *lru) clear() {
+	c.order.Init()
+	c.entries = map[string]*list.Element{}
+	c.cost = 0
+}
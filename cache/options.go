@@ -0,0 +1,32 @@
+package cache
+
+import "time"
+
+// Option configures a [Cache].
+type Option func(*options)
+
+type options struct {
+	// Returns the current time.
+	clock func() time.Time
+}
+
+func newOptions(opts []Option) options {
+	o := options{
+		clock: time.Now,
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// WithClock sets the function used by the cache to get the current time, used to determine
+// when keys written with a time-to-live have expired and may be cached.
+//
+// This should match the clock of the underlying store, and allows expiry to be tested
+// deterministically.  Defaults to [time.Now].
+func WithClock(now func() time.Time) Option {
+	return func(o *options) {
+		o.clock = now
+	}
+}
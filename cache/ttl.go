@@ -0,0 +1,30 @@
+package cache
+
+import (
+	"context"
+	"time"
+
+	"github.com/sourcenetwork/corekv"
+)
+
+// minExpirySweep is the minimum number of expiries that must be held before expired keys are
+// removed from them.
+const minExpirySweep = 64
+
+var _ corekv.TTLWriter = (*cacheStore)(nil)
+
+// SetWithTTL sets the value of the given key in the underlying store, the key will not be
+// cached until the given time-to-live has elapsed.
+//
+// If the underlying store does not support expiring items [corekv.ErrNotSupported] will be
+// returned.
+func (s *cacheStore) SetWithTTL(ctx context.Context, key []byte, value []byte, ttl time.Duration) error {
+	store, ok := s.store.(corekv.TTLWriter)
+	if !ok {
+		return corekv.ErrNotSupported
+	}
+
+	err := store.SetWithTTL(ctx, key, value, ttl)
+	s.written(err == nil, [][]byte{key}, map[string]time.Duration{string(key): ttl})
+	return err
+}
@@ -0,0 +1,145 @@
+package cache
+
+import (
+	"context"
+	"time"
+
+	"github.com/sourcenetwork/corekv"
+)
+
+// cacheTxnStore is a [Cache] over a [corekv.TxnStore].
+type cacheTxnStore struct {
+	*cacheStore
+}
+
+var _ corekv.TxnStore = (*cacheTxnStore)(nil)
+
+// NewTxn returns a new transaction over the underlying store.
+//
+// Reads made within the transaction do not make use of the cache, as they must observe the
+// transaction's own writes.  Once the transaction has been successfully committed, all the
+// keys written by it will be invalidated.
+func (s *cacheTxnStore) NewTxn(readonly bool) corekv.Txn {
+	return &cacheTxn{
+		Txn:   s.store.(corekv.TxnStore).NewTxn(readonly),
+		store: s.cacheStore,
+	}
+}
+
+type cacheTxn struct {
+	corekv.Txn
+	store *cacheStore
+
+	// The keys written to by this transaction.
+	keys [][]byte
+	// The time-to-lives of the keys last written with one by this transaction.
+	ttls map[string]time.Duration
+}
+
+var (
+	_ corekv.Txn         = (*cacheTxn)(nil)
+	_ corekv.MultiReader = (*cacheTxn)(nil)
+	_ corekv.TTLWriter   = (*cacheTxn)(nil)
+)
+
+func (t *cacheTxn) Set(ctx context.Context, key []byte, value []byte) error {
+	err := t.Txn.Set(ctx, key, value)
+	if err != nil {
+		return err
+	}
+
+	t.record(key, nil)
+	return nil
+}
+
+func (t *cacheTxn) Delete(ctx context.Context, key []byte) error {
+	err := t.Txn.Delete(ctx, key)
+	if err != nil {
+		return err
+	}
+
+	t.record(key, nil)
+	return nil
+}
+
+// SetWithTTL sets the value of the given key within the transaction, once the transaction
+// has been committed the key will not be cached until the given time-to-live has elapsed.
+//
+// If the underlying transaction does not support expiring items [corekv.ErrNotSupported]
+// will be returned.
+func (t *cacheTxn) SetWithTTL(ctx context.Context, key []byte, value []byte, ttl time.Duration) error {
+	txn, ok := t.Txn.(corekv.TTLWriter)
+	if !ok {
+		return corekv.ErrNotSupported
+	}
+
+	err := txn.SetWithTTL(ctx, key, value, ttl)
+	if err != nil {
+		return err
+	}
+
+	t.record(key, &ttl)
+	return nil
+}
+
+// GetMany returns the values at the given keys from the underlying transaction.
+//
+// If the underlying transaction does not support reading many items [corekv.ErrNotSupported]
+// will be returned.
+func (t *cacheTxn) GetMany(ctx context.Context, keys [][]byte) ([]corekv.GetResult, error) {
+	txn, ok := t.Txn.(corekv.MultiReader)
+	if !ok {
+		return nil, corekv.ErrNotSupported
+	}
+
+	return txn.GetMany(ctx, keys)
+}
+
+// HasMany returns true for each of the given keys at which an item is found in the
+// underlying transaction.
+//
+// If the underlying transaction does not support reading many items [corekv.ErrNotSupported]
+// will be returned.
+func (t *cacheTxn) HasMany(ctx context.Context, keys [][]byte) ([]bool, error) {
+	txn, ok := t.Txn.(corekv.MultiReader)
+	if !ok {
+		return nil, corekv.ErrNotSupported
+	}
+
+	return txn.HasMany(ctx, keys)
+}
+
+// record records that the given key has been written, with the given time-to-live if it is
+// not nil.
+func (t *cacheTxn) record(key []byte, ttl *time.Duration) {
+	t.keys = append(t.keys, copyBytes(key))
+
+	if ttl != nil {
+		if t.ttls == nil {
+			t.ttls = map[string]time.Duration{}
+		}
+		t.ttls[string(key)] = *ttl
+	} else {
+		delete(t.ttls, string(key))
+	}
+}
+
+func (t *cacheTxn) Commit(ctx context.Context) error {
+	err := t.Txn.Commit(ctx)
+
+	// The keys are invalidated even if the commit fails, as it may have been partially
+	// applied by the underlying store.
+	if len(t.keys) > 0 {
+		t.store.written(err == nil, t.keys, t.ttls)
+		t.keys = nil
+		t.ttls = nil
+	}
+
+	return err
+}
+
+func (t *cacheTxn) Discard(ctx context.Context) error {
+	t.keys = nil
+	t.ttls = nil
+	return t.Txn.Discard(ctx)
+}
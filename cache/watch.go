@@ -0,0 +1,22 @@
+package cache
+
+import (
+	"context"
+
+	"github.com/sourcenetwork/corekv"
+)
+
+var _ corekv.Watchable = (*cacheStore)(nil)
+
+// Watch returns a channel yielding the changes committed to items with the given prefix in
+// the underlying store.
+//
+// If the underlying store does not support watching [corekv.ErrNotSupported] will be returned.
+func (s *cacheStore) Watch(ctx context.Context, prefix []byte) (<-chan corekv.Event, error) {
+	store, ok := s.store.(corekv.Watchable)
+	if !ok {
+		return nil, corekv.ErrNotSupported
+	}
+
+	return store.Watch(ctx, prefix)
+}
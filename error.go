@@ -8,4 +8,26 @@ var (
 	ErrValueNil     = fmt.Errorf("kv: value is nil")
 	ErrDiscardedTxn = fmt.Errorf("kv: transaction discarded")
 	ErrDBClosed     = fmt.Errorf("kv: datastore closed")
+	ErrNotSupported = fmt.Errorf("kv: operation not supported")
+	ErrInvalidRange = fmt.Errorf("kv: invalid range")
+
+	ErrInvalidResumeToken = fmt.Errorf("kv: invalid resume token")
+	ErrTxnConflict        = fmt.Errorf("kv: transaction conflict")
+	ErrTxnTooBig          = fmt.Errorf("kv: transaction too big")
+	ErrWatchOverflow      = fmt.Errorf("kv: watch overflowed, changes were missed")
 )
+
+// InvalidRangeError is returned by iterators created with an [IterOptions.Start] value
+// greater than the [IterOptions.End] value.
+type InvalidRangeError struct {
+	Start []byte
+	End   []byte
+}
+
+func (e *InvalidRangeError) Error() string {
+	return fmt.Sprintf("%s: start %q is greater than end %q", ErrInvalidRange, e.Start, e.End)
+}
+
+func (e *InvalidRangeError) Unwrap() error {
+	return ErrInvalidRange
+}
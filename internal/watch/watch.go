@@ -0,0 +1,137 @@
+// Package watch delivers the changes committed to a store to its watchers.
+package watch
+
+import (
+	"bytes"
+	"context"
+	"sync"
+
+	"github.com/sourcenetwork/corekv"
+)
+
+// DefaultQueueSize is the default maximum number of commits whose events may be queued for
+// delivery to each watcher.
+const DefaultQueueSize = 1024
+
+// Watcher delivers the changes committed to items with a given prefix.
+//
+// Events are queued without blocking the committer and delivered, in order, via the events
+// channel by [Watcher.Deliver].  The events of up to queueSize commits may be queued, if the
+// queue is full when further changes are published the watcher overflows: the changes are
+// dropped and, once the queued events have been delivered, a final event holding
+// [corekv.ErrWatchOverflow] is yielded before the channel is closed.
+type Watcher struct {
+	prefix    []byte
+	events    chan corekv.Event
+	queueSize int
+
+	// lk guards all the fields below it.
+	lk    sync.Mutex
+	queue []corekv.Event
+	// The number of commits whose events are held in the queue.
+	queuedCommits int
+	// Set if the queue was full when events were published, no further events will be
+	// queued.
+	overflowed bool
+
+	// notify is signalled when events are added to the queue.
+	notify chan struct{}
+}
+
+// New returns a new [Watcher] of the items with the given prefix, queueing the events of up
+// to queueSize commits.
+func New(prefix []byte, queueSize int) *Watcher {
+	if queueSize < 1 {
+		queueSize = 1
+	}
+
+	return &Watcher{
+		prefix:    prefix,
+		events:    make(chan corekv.Event),
+		queueSize: queueSize,
+		notify:    make(chan struct{}, 1),
+	}
+}
+
+// Events returns the channel via which events are delivered.
+func (w *Watcher) Events() <-chan corekv.Event {
+	return w.events
+}
+
+// Publish queues the given events, describing changes committed together, for delivery if
+// their keys have the watcher's prefix.
+//
+// Publish never blocks, and must be called in commit order.
+func (w *Watcher) Publish(events []corekv.Event) {
+	var matched []corekv.Event
+	for _, event := range events {
+		if bytes.HasPrefix(event.Key, w.prefix) {
+			matched = append(matched, event)
+		}
+	}
+	if len(matched) == 0 {
+		return
+	}
+
+	w.lk.Lock()
+	if w.queuedCommits >= w.queueSize {
+		w.overflowed = true
+	}
+	if !w.overflowed {
+		w.queue = append(w.queue, matched...)
+		w.queuedCommits++
+	}
+	w.lk.Unlock()
+
+	select {
+	case w.notify <- struct{}{}:
+	default:
+		// The watcher has already been notified and has not yet drained its queue.
+	}
+}
+
+// Deliver sends the queued events to the events channel until either the given context is
+// cancelled, the given done channel is closed, or the watcher overflows, closing the events
+// channel on return.
+func (w *Watcher) Deliver(ctx context.Context, done <-chan struct{}) {
+	defer close(w.events)
+
+	send := func(event corekv.Event) bool {
+		select {
+		case w.events <- event:
+			return true
+		case <-ctx.Done():
+			return false
+		case <-done:
+			return false
+		}
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-done:
+			return
+		case <-w.notify:
+		}
+
+		w.lk.Lock()
+		events := w.queue
+		overflowed := w.overflowed
+		w.queue = nil
+		w.queuedCommits = 0
+		w.lk.Unlock()
+
+		for _, event := range events {
+			if !send(event) {
+				return
+			}
+		}
+
+		if overflowed {
+			send(corekv.Event{Err: corekv.ErrWatchOverflow})
+			return
+		}
+	}
+}
@@ -1,6 +1,9 @@
 package corekv
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 // DefaultIterOptions is exactly the default zero value
 // for the IterOptions stuct. It is however recomended
@@ -16,44 +19,58 @@ type IterOptions struct {
 	// Prefix iteration, only keys beginning with the designated prefix
 	// with the given prefix will be yielded.
 	//
-	// Keys exactly matching the provided `Prefix` value will not be
-	// yielded.
+	// Keys exactly matching the provided `Prefix` value will be yielded.
 	//
-	// Providing a Prefix value should cause the Start and End options
-	// to be ignored, although this is currently untested:
-	// https://github.com/sourcenetwork/corekv/issues/35
+	// If Start and/or End are also provided, only keys that both begin with the
+	// prefix and fall within the Start-End range will be yielded.
 	Prefix []byte
 
-	// If Prefix is nil, and Start is provided, the iterator will
-	// only yield items with a key lexographically greater than or
-	// equal to this value.
+	// If Start is provided, the iterator will only yield items with a key
+	// lexographically greater than or equal to this value, or greater than
+	// this value if StartExclusive is true.
 	//
-	// Providing an `End` value equal to or smaller than this value
-	// will result in undefined behaviour:
-	// https://github.com/sourcenetwork/corekv/issues/32
+	// Providing a Start value greater than End will cause the iterator to
+	// return an [*InvalidRangeError].
 	Start []byte
 
-	// If Prefix is nil, and End is provided, the iterator will
-	// only yield items with a key lexographically smaller than this
-	// value.
+	// If End is provided, the iterator will only yield items with a key
+	// lexographically smaller than this value, or smaller than or equal to
+	// this value if EndInclusive is true.
 	//
-	// Providing an End value equal to or smaller than Start
-	// will result in undefined behaviour:
-	// https://github.com/sourcenetwork/corekv/issues/32
+	// Providing an End value smaller than Start will cause the iterator to
+	// return an [*InvalidRangeError].
 	End []byte
 
+	// If true, items with a key equal to Start will not be yielded.
+	StartExclusive bool
+
+	// If true, items with a key equal to End will be yielded.
+	EndInclusive bool
+
+	// If greater than zero, the iterator will yield at most this many items.
+	//
+	// The limit applies to the items yielded by `Next` from the beginning of iteration,
+	// or from the item moved to by `Seek`.
+	Limit int
+
+	// The number of items to skip over at the beginning of iteration.
+	//
+	// Offset is not applied when moving the iterator using `Seek`.
+	Offset int
+
+	// If provided, iteration will resume from the item following the key that the
+	// token was created with by [NewResumeToken].
+	//
+	// The token must have been created using options with the same `Reverse` value,
+	// otherwise the iterator will return [ErrInvalidResumeToken].
+	ResumeToken []byte
+
 	// Reverse the direction of the iteration, returning items in
 	// lexographically descending order of their keys.
 	Reverse bool
 
 	// Only iterate through keys. Calling Value on the
 	// iterator will return nil and no error.
-	//
-	// This option is currently untested:
-	// https://github.com/sourcenetwork/corekv/issues/34
-	//
-	// It is very likely ignored for the memory store iteration:
-	// https://github.com/sourcenetwork/corekv/issues/33
 	KeysOnly bool
 }
 
@@ -73,6 +90,35 @@ type Reader interface {
 	Iterator(ctx context.Context, opts IterOptions) Iterator
 }
 
+// MultiReader represents a [Reader] that supports reading many items in a single
+// operation.
+//
+// All the items are read from the same state of the store.
+type MultiReader interface {
+	Reader
+
+	// GetMany returns the values at the given keys.
+	//
+	// The results are returned in the same order as the given keys, if no item is
+	// found at a key the `Found` property of its result will be false.
+	GetMany(ctx context.Context, keys [][]byte) ([]GetResult, error)
+
+	// HasMany returns true for each of the given keys at which an item is found,
+	// otherwise false.
+	//
+	// The results are returned in the same order as the given keys.
+	HasMany(ctx context.Context, keys [][]byte) ([]bool, error)
+}
+
+// GetResult is the result of reading a single item via [MultiReader.GetMany].
+type GetResult struct {
+	// The value of the item, nil if no item was found.
+	Value []byte
+
+	// Found is true if an item exists at the given key.
+	Found bool
+}
+
 // Writer contains functions for mutating values within a store.
 type Writer interface {
 	// Set sets the value stored against the given key.
@@ -87,6 +133,66 @@ type Writer interface {
 	Delete(ctx context.Context, key []byte) error
 }
 
+// TTLWriter represents a [Writer] that supports setting items that expire after a
+// given duration.
+type TTLWriter interface {
+	Writer
+
+	// SetWithTTL sets the value stored against the given key, the item will expire
+	// once the given time-to-live has passed, after which it will no longer be
+	// readable.
+	//
+	// If an item already exists at the given key it will be overwritten.
+	//
+	// The precision of the time-to-live is implementation dependent, for example
+	// Badger truncates expiry times to the second, so items may expire up to a second
+	// before the time-to-live has passed.
+	SetWithTTL(ctx context.Context, key, value []byte, ttl time.Duration) error
+}
+
+// RangeDeleter represents a [Writer] that supports removing all the items within a
+// range of keys in a single, atomic, operation.
+type RangeDeleter interface {
+	Writer
+
+	// DeleteRange removes all items with a key lexographically greater than or equal
+	// to start, and smaller than end.
+	//
+	// If start is nil items will be removed from the beginning of the store, if end
+	// is nil items will be removed up to and including the end of the store.
+	//
+	// If start is greater than end an [*InvalidRangeError] will be returned.
+	DeleteRange(ctx context.Context, start, end []byte) error
+
+	// DeletePrefix removes all items with a key beginning with the given prefix,
+	// including the item exactly matching the prefix.
+	//
+	// An empty prefix will result in an [ErrEmptyKey] error.
+	DeletePrefix(ctx context.Context, prefix []byte) error
+}
+
+// ConditionalWriter represents a [Writer] that supports atomic, conditional, writes.
+//
+// Each function reads the current value of the key and writes the new state as a single
+// atomic operation, returning true if the write was made.
+type ConditionalWriter interface {
+	Writer
+
+	// CompareAndSwap sets the value stored against the given key to newValue, if the
+	// currently stored value is equal to expectedOld.
+	//
+	// If no item exists at the given key, no value will be set and false will be returned.
+	CompareAndSwap(ctx context.Context, key, expectedOld, newValue []byte) (bool, error)
+
+	// SetIfAbsent sets the value stored against the given key, if no item currently
+	// exists at that key.
+	SetIfAbsent(ctx context.Context, key, value []byte) (bool, error)
+
+	// DeleteIfEquals removes the item at the given key, if the currently stored value
+	// is equal to expected.
+	DeleteIfEquals(ctx context.Context, key, expected []byte) (bool, error)
+}
+
 // Iterator is a read-only iterator that allows iteration over the underlying
 // store (or a part of it).
 //
@@ -124,7 +230,34 @@ type Iterator interface {
 	Close(ctx context.Context) error
 }
 
+// BidirectionalIterator represents an [Iterator] that may also be moved backwards,
+// against the direction of iteration.
+//
+// Moving backwards past the first item returns the iterator to its initial location, the
+// following `Next` call will then yield the first item.  Moving backwards after `Next`
+// has returned `false` will yield the last item.
+type BidirectionalIterator interface {
+	Iterator
+
+	// Prev attempts to move the iterator backwards, it will return `true` if it was
+	// successful, otherwise `false`.
+	Prev() (bool, error)
+
+	// Peek returns the key of the item that would be yielded by the next `Next` call,
+	// without moving the iterator.
+	//
+	// Peek will return `true` if there is a next item, otherwise `false`.
+	Peek() ([]byte, bool, error)
+}
+
 // Store contains all the functions required for interacting with a store.
+//
+// Optional functionality is provided by stores implementing further interfaces, such as
+// [Batchable] and [Watchable], which callers may detect via type assertions.  Stores that
+// wrap another store, such as namespaced stores, may implement these interfaces regardless
+// of whether the wrapped store does, returning [ErrNotSupported] from any function that the
+// wrapped store does not support.  The exception is [TxnStore], which is only implemented
+// by a wrapping store if the wrapped store implements it.
 type Store interface {
 	Reader
 	Writer
@@ -144,6 +277,76 @@ type TxnStore interface {
 	NewTxn(readonly bool) Txn
 }
 
+// Batchable represents a [Store] that supports atomic write batches.
+type Batchable interface {
+	Store
+
+	// Batch returns a new, empty, batch.
+	Batch(ctx context.Context) (Batch, error)
+}
+
+// Batch collects write operations made via this object so that they may be
+// applied to the underlying store atomically, when `Commit` is called.
+//
+// Operations made via a Batch are not readable until they have been committed.
+//
+// Implementations may limit the total size of the operations collected by a Batch, for
+// example Badger limits it to a fraction of its memtable size.  An operation that would
+// exceed the limit is not collected and returns [ErrTxnTooBig], the operations collected
+// before it may still be committed, and the remainder written via a new Batch.
+type Batch interface {
+	Writer
+
+	// Commit applies all the operations collected by this [Batch] to the
+	// underlying [Store].
+	Commit(ctx context.Context) error
+
+	// Discard discards all the operations collected by this [Batch] so far,
+	// releasing any resources held by it.
+	Discard(ctx context.Context) error
+}
+
+// Watchable represents a [Store] that supports subscribing to the changes committed
+// to it.
+type Watchable interface {
+	Store
+
+	// Watch returns a channel that yields an [Event] for each Set or Delete committed
+	// to an item with a key beginning with the given prefix, in commit order.  Changes
+	// committed together are yielded in lexographical order of their keys.
+	//
+	// A nil or empty prefix will watch all items.
+	//
+	// The channel will be closed once the given context is cancelled, or the store is
+	// closed.  The channel must be drained by the caller, else the delivery of events
+	// may be delayed indefinitely.
+	//
+	// Implementations may bound the number of events held for a caller that does not keep
+	// up with the changes committed.  If the bound is exceeded the caller will have missed
+	// changes, a final [Event] with `Err` set to [ErrWatchOverflow] will be yielded before
+	// the channel is closed, after which the caller may watch again and re-read any state
+	// that it holds.
+	Watch(ctx context.Context, prefix []byte) (<-chan Event, error)
+}
+
+// Event describes a change committed to an item in a [Watchable] store.
+type Event struct {
+	// The key of the changed item.
+	Key []byte
+
+	// The new value of the item.
+	//
+	// This will be nil if the item was deleted.
+	Value []byte
+
+	// IsDeleted will be true if the item was deleted.
+	IsDeleted bool
+
+	// Err is set on the final event yielded by a watch that has ended before its context
+	// was cancelled, for example [ErrWatchOverflow].  No other fields are set on such events.
+	Err error
+}
+
 // Txn isolates changes made to the underlying store from this object,
 // and isolates changes made via this object from the underlying store
 // until `Commit` is called.
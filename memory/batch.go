@@ -0,0 +1,79 @@
+package memory
+
+import (
+	"context"
+
+	"github.com/sourcenetwork/corekv"
+	"github.com/tidwall/btree"
+)
+
+// basicBatch implements corekv.Batch
+type basicBatch struct {
+	ops *btree.BTreeG[dsItem]
+	ds  *Datastore
+	// done is true if the batch has been committed or discarded.
+	done bool
+}
+
+var _ corekv.Batch = (*basicBatch)(nil)
+
+// Set implements corekv.Writer.
+func (b *basicBatch) Set(ctx context.Context, key []byte, value []byte) error {
+	if b.done {
+		return ErrTxnDiscarded
+	}
+	if len(key) == 0 {
+		return corekv.ErrEmptyKey
+	}
+
+	b.ops.Set(dsItem{key: key, val: value})
+	return nil
+}
+
+// Delete implements corekv.Writer.
+//
+// Unlike transactions, a batch does not read from the datastore, so deleting a key
+// that does not exist will still record a (harmless) deletion.
+func (b *basicBatch) Delete(ctx context.Context, key []byte) error {
+	if b.done {
+		return ErrTxnDiscarded
+	}
+	if len(key) == 0 {
+		return corekv.ErrEmptyKey
+	}
+
+	b.ops.Set(dsItem{key: key, isDeleted: true})
+	return nil
+}
+
+// Commit writes all the operations in the batch to the datastore at a single version.
+func (b *basicBatch) Commit(ctx context.Context) error {
+	b.ds.closeLk.RLock()
+	defer b.ds.closeLk.RUnlock()
+	if b.ds.closed {
+		return ErrClosed
+	}
+	if b.done {
+		return ErrTxnDiscarded
+	}
+	defer b.Discard(ctx)
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	b.ds.commitLk.Lock()
+	defer b.ds.commitLk.Unlock()
+
+	return b.ds.write(b.ops)
+}
+
+// Discard removes all the operations added to the batch.
+func (b *basicBatch) Discard(ctx context.Context) error {
+	if b.done {
+		return nil
+	}
+	b.ops.Clear()
+	b.done = true
+	return nil
+}
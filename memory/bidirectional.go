@@ -0,0 +1,148 @@
+package memory
+
+import (
+	"bytes"
+
+	"github.com/sourcenetwork/corekv"
+)
+
+var _ corekv.BidirectionalIterator = (*iterator)(nil)
+var _ corekv.BidirectionalIterator = (*txnIterator)(nil)
+
+// cursor is implemented by the iterators in this package, allowing them to be moved
+// backwards by temporarily flipping their direction of iteration.
+type cursor interface {
+	corekv.Iterator
+
+	// isReset returns true if the iterator will be returned to the beginning on the next
+	// `Next` call.
+	isReset() bool
+
+	// currentKey returns the key at the current iterator location, or nil if the iterator
+	// has been exhausted.
+	currentKey() []byte
+
+	// restart returns the iterator back to it's initial location, in it's current
+	// direction of iteration, and moves it onto the first item.
+	restart() (bool, error)
+
+	// flip reverses the direction of iteration.
+	flip()
+}
+
+func (iter *iterator) Prev() (bool, error) {
+	return prev(iter)
+}
+
+func (iter *iterator) Peek() ([]byte, bool, error) {
+	return peek(iter)
+}
+
+func (iter *iterator) isReset() bool {
+	return iter.reset
+}
+
+func (iter *iterator) currentKey() []byte {
+	return iter.item.key
+}
+
+func (iter *iterator) flip() {
+	iter.reverse = !iter.reverse
+}
+
+func (iter *txnIterator) Prev() (bool, error) {
+	return prev(iter)
+}
+
+func (iter *txnIterator) Peek() ([]byte, bool, error) {
+	return peek(iter)
+}
+
+func (iter *txnIterator) isReset() bool {
+	return iter.reset
+}
+
+func (iter *txnIterator) currentKey() []byte {
+	if iter.current == nil {
+		return nil
+	}
+	return iter.current.Key()
+}
+
+func (iter *txnIterator) flip() {
+	iter.reverse = !iter.reverse
+	iter.snapshot.flip()
+	iter.pending.flip()
+}
+
+// prev moves the given cursor backwards by one item.
+//
+// This is done by flipping the direction of the cursor and seeking past the current key,
+// before flipping it back and seeking to the found key.  This leaves the underlying
+// btree iterators positioned as they would have been had the found key been reached
+// through `Next`, and avoids stepping them beyond their ends, see `TestBTreePrevBug`.
+func prev(c cursor) (bool, error) {
+	if c.isReset() {
+		return false, nil
+	}
+
+	key := c.currentKey()
+
+	c.flip()
+	var hasPrev bool
+	var err error
+	if key == nil {
+		// The iterator has been exhausted, so the previous item is the last one.
+		hasPrev, err = c.restart()
+	} else {
+		hasPrev, err = c.Seek(key)
+		if err == nil && hasPrev && bytes.Equal(c.Key(), key) {
+			hasPrev, err = c.Next()
+		}
+	}
+	c.flip()
+
+	if err != nil {
+		return false, err
+	}
+	if !hasPrev {
+		c.Reset()
+		return false, nil
+	}
+
+	return c.Seek(c.Key())
+}
+
+// peek returns the key that the next `Next` call on the given cursor would yield, without
+// moving the cursor.
+func peek(c cursor) ([]byte, bool, error) {
+	if c.isReset() {
+		hasNext, err := c.restart()
+		c.Reset()
+		if err != nil || !hasNext {
+			return nil, false, err
+		}
+		return c.Key(), true, nil
+	}
+
+	key := c.currentKey()
+	if key == nil {
+		return nil, false, nil
+	}
+
+	hasNext, err := c.Next()
+	if err != nil {
+		return nil, false, err
+	}
+	var next []byte
+	if hasNext {
+		next = c.Key()
+	}
+
+	_, err = c.Seek(key)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return next, hasNext, nil
+}
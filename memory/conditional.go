@@ -0,0 +1,70 @@
+package memory
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/sourcenetwork/corekv"
+	"github.com/tidwall/btree"
+)
+
+var _ corekv.ConditionalWriter = (*Datastore)(nil)
+
+// CompareAndSwap implements corekv.ConditionalWriter.
+func (d *Datastore) CompareAndSwap(ctx context.Context, key, expectedOld, newValue []byte) (bool, error) {
+	return d.writeIf(ctx, key, func(item dsItem, exists bool) bool {
+		return exists && bytes.Equal(item.val, expectedOld)
+	}, dsItem{key: key, val: newValue})
+}
+
+// SetIfAbsent implements corekv.ConditionalWriter.
+func (d *Datastore) SetIfAbsent(ctx context.Context, key, value []byte) (bool, error) {
+	return d.writeIf(ctx, key, func(_ dsItem, exists bool) bool {
+		return !exists
+	}, dsItem{key: key, val: value})
+}
+
+// DeleteIfEquals implements corekv.ConditionalWriter.
+func (d *Datastore) DeleteIfEquals(ctx context.Context, key, expected []byte) (bool, error) {
+	return d.writeIf(ctx, key, func(item dsItem, exists bool) bool {
+		return exists && bytes.Equal(item.val, expected)
+	}, dsItem{key: key, isDeleted: true})
+}
+
+// writeIf writes the given item to the datastore at a new version if the given condition
+// is met by the latest version of the item's key.
+//
+// The commitLk is held throughout, so the condition cannot be invalidated by another commit
+// before the item is written.
+func (d *Datastore) writeIf(ctx context.Context, key []byte, condition func(item dsItem, exists bool) bool, item dsItem) (bool, error) {
+	d.closeLk.RLock()
+	defer d.closeLk.RUnlock()
+	if d.closed {
+		return false, ErrClosed
+	}
+	if len(key) == 0 {
+		return false, corekv.ErrEmptyKey
+	}
+
+	if err := ctx.Err(); err != nil {
+		return false, err
+	}
+
+	d.commitLk.Lock()
+	defer d.commitLk.Unlock()
+
+	current := d.get(key, d.getVersion())
+	exists := current.key != nil && !current.isDeleted
+	if !condition(current, exists) {
+		return false, nil
+	}
+
+	ops := btree.NewBTreeG(byKeys)
+	ops.Set(item)
+	err := d.write(ops)
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
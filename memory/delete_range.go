@@ -0,0 +1,73 @@
+package memory
+
+import (
+	"context"
+
+	"github.com/sourcenetwork/corekv"
+	"github.com/tidwall/btree"
+)
+
+var _ corekv.RangeDeleter = (*Datastore)(nil)
+
+// DeleteRange implements corekv.RangeDeleter.
+//
+// All the items within the range are deleted at a single, new, version.
+func (d *Datastore) DeleteRange(ctx context.Context, start, end []byte) error {
+	d.closeLk.RLock()
+	defer d.closeLk.RUnlock()
+	if d.closed {
+		return ErrClosed
+	}
+
+	return d.deleteRange(ctx, corekv.IterOptions{Start: start, End: end})
+}
+
+// DeletePrefix implements corekv.RangeDeleter.
+//
+// All the items with the given prefix are deleted at a single, new, version.
+func (d *Datastore) DeletePrefix(ctx context.Context, prefix []byte) error {
+	d.closeLk.RLock()
+	defer d.closeLk.RUnlock()
+	if d.closed {
+		return ErrClosed
+	}
+	if len(prefix) == 0 {
+		return corekv.ErrEmptyKey
+	}
+
+	return d.deleteRange(ctx, corekv.IterOptions{Prefix: prefix})
+}
+
+// deleteRange writes a deletion for each of the items yielded by an iterator with the
+// given options.
+func (d *Datastore) deleteRange(ctx context.Context, opts corekv.IterOptions) error {
+	d.commitLk.Lock()
+	defer d.commitLk.Unlock()
+
+	ops := btree.NewBTreeG(byKeys)
+
+	// The iterator must be closed before writing the deletions, as it holds a read lock on
+	// the values.
+	iter := newIter(ctx, d.values, opts, d.getVersion(), d.now)
+	for {
+		hasValue, err := iter.Next()
+		if err != nil {
+			iter.Close(ctx)
+			return err
+		}
+		if !hasValue {
+			break
+		}
+		ops.Set(dsItem{key: iter.Key(), isDeleted: true})
+	}
+	err := iter.Close(ctx)
+	if err != nil {
+		return err
+	}
+
+	if ops.Len() == 0 {
+		return nil
+	}
+
+	return d.write(ops)
+}
@@ -0,0 +1,180 @@
+package memory
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+	"io"
+	"time"
+)
+
+// Flags describing an encoded item.
+const (
+	itemFlagDeleted   byte = 1 << 0
+	itemFlagNilValue  byte = 1 << 1
+	itemFlagExpiresAt byte = 1 << 2
+)
+
+// recordHeaderSize is the size of the length and checksum preceding each record payload.
+const recordHeaderSize = 8
+
+// errCorruptRecord is returned when reading a record that is incomplete, or that fails its
+// checksum.
+var errCorruptRecord = errors.New("corrupt record")
+
+// appendRecord appends the given items, committed at the given version, to dst as a single
+// length-prefixed and checksummed record.
+func appendRecord(dst []byte, version uint64, items []dsItem) []byte {
+	payload := binary.AppendUvarint(nil, version)
+	payload = binary.AppendUvarint(payload, uint64(len(items)))
+	for _, item := range items {
+		payload = appendItem(payload, item)
+	}
+
+	dst = binary.LittleEndian.AppendUint32(dst, uint32(len(payload)))
+	dst = binary.LittleEndian.AppendUint32(dst, crc32.ChecksumIEEE(payload))
+	return append(dst, payload...)
+}
+
+func appendItem(dst []byte, item dsItem) []byte {
+	var flags byte
+	if item.isDeleted {
+		flags |= itemFlagDeleted
+	} else if item.val == nil {
+		flags |= itemFlagNilValue
+	}
+	if !item.expiresAt.IsZero() {
+		flags |= itemFlagExpiresAt
+	}
+
+	dst = append(dst, flags)
+	dst = binary.AppendUvarint(dst, uint64(len(item.key)))
+	dst = append(dst, item.key...)
+	if flags&(itemFlagDeleted|itemFlagNilValue) == 0 {
+		dst = binary.AppendUvarint(dst, uint64(len(item.val)))
+		dst = append(dst, item.val...)
+	}
+	if flags&itemFlagExpiresAt != 0 {
+		dst = binary.AppendVarint(dst, item.expiresAt.UnixNano())
+	}
+	return dst
+}
+
+// readRecord reads a single record from the given reader, returning the version at which its
+// items were committed, and the total size of the record in bytes.
+//
+// [io.EOF] is returned if the reader is exhausted before the record begins, and
+// [errCorruptRecord] if the record is incomplete or invalid.
+func readRecord(r *bufio.Reader) (uint64, []dsItem, int, error) {
+	var header [recordHeaderSize]byte
+	_, err := io.ReadFull(r, header[:])
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return 0, nil, 0, io.EOF
+		}
+		return 0, nil, 0, errCorruptRecord
+	}
+
+	payload := make([]byte, binary.LittleEndian.Uint32(header[0:4]))
+	_, err = io.ReadFull(r, payload)
+	if err != nil {
+		return 0, nil, 0, errCorruptRecord
+	}
+	if crc32.ChecksumIEEE(payload) != binary.LittleEndian.Uint32(header[4:8]) {
+		return 0, nil, 0, errCorruptRecord
+	}
+
+	d := decoder{buf: payload}
+	version := d.uvarint()
+	count := d.uvarint()
+	if d.err != nil || count > uint64(len(payload)) {
+		return 0, nil, 0, errCorruptRecord
+	}
+
+	items := make([]dsItem, 0, count)
+	for i := uint64(0); i < count; i++ {
+		item := d.item()
+		if d.err != nil {
+			return 0, nil, 0, errCorruptRecord
+		}
+		item.version = version
+		items = append(items, item)
+	}
+	if len(d.buf) != 0 {
+		return 0, nil, 0, errCorruptRecord
+	}
+
+	return version, items, recordHeaderSize + len(payload), nil
+}
+
+// decoder reads values from a record payload, holding on to the first error encountered.
+type decoder struct {
+	buf []byte
+	err error
+}
+
+func (d *decoder) uvarint() uint64 {
+	if d.err != nil {
+		return 0
+	}
+	v, n := binary.Uvarint(d.buf)
+	if n <= 0 {
+		d.err = errCorruptRecord
+		return 0
+	}
+	d.buf = d.buf[n:]
+	return v
+}
+
+func (d *decoder) varint() int64 {
+	if d.err != nil {
+		return 0
+	}
+	v, n := binary.Varint(d.buf)
+	if n <= 0 {
+		d.err = errCorruptRecord
+		return 0
+	}
+	d.buf = d.buf[n:]
+	return v
+}
+
+func (d *decoder) bytes() []byte {
+	length := d.uvarint()
+	if d.err != nil {
+		return nil
+	}
+	if length > uint64(len(d.buf)) {
+		d.err = errCorruptRecord
+		return nil
+	}
+	b := make([]byte, length)
+	copy(b, d.buf)
+	d.buf = d.buf[length:]
+	return b
+}
+
+func (d *decoder) item() dsItem {
+	if d.err != nil {
+		return dsItem{}
+	}
+	if len(d.buf) == 0 {
+		d.err = errCorruptRecord
+		return dsItem{}
+	}
+	flags := d.buf[0]
+	d.buf = d.buf[1:]
+
+	item := dsItem{
+		key:       d.bytes(),
+		isDeleted: flags&itemFlagDeleted != 0,
+	}
+	if flags&(itemFlagDeleted|itemFlagNilValue) == 0 {
+		item.val = d.bytes()
+	}
+	if flags&itemFlagExpiresAt != 0 {
+		item.expiresAt = time.Unix(0, d.varint())
+	}
+	return item
+}
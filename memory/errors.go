@@ -10,12 +10,59 @@
 
 package memory
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+
+	"github.com/sourcenetwork/corekv"
+)
 
 var (
 	ErrReadOnlyTxn  = errors.New("read only transaction")
 	ErrTxnDiscarded = errors.New("transaction discarded")
 	//nolint:revive
 	ErrTxnConflict = errors.New("transaction Conflict. Please retry")
-	ErrClosed      = errors.New("datastore closed")
+	// ErrClosed wraps [corekv.ErrDBClosed], allowing closed datastores to be detected
+	// without depending on the memory package.
+	ErrClosed error = &closedError{}
+
+	ErrVersionPurged  = errors.New("version has been purged")
+	ErrInvalidVersion = errors.New("version has not yet been committed")
+	ErrSnapshotClosed = errors.New("snapshot closed")
+
+	ErrCorruptSnapshotFile = errors.New("snapshot file is corrupt")
+	ErrWALFailed           = errors.New("write-ahead log could not be restored after a failed write")
+	ErrLocked              = errors.New("datastore directory is locked by another datastore")
 )
+
+// TxnConflictError is returned when committing a transaction that read a key that has since
+// been written to by another transaction.
+type TxnConflictError struct {
+	// The key that was written to.
+	Key []byte
+}
+
+func (e *TxnConflictError) Error() string {
+	return fmt.Sprintf("%s: key %q", ErrTxnConflict, e.Key)
+}
+
+// Unwrap returns both [ErrTxnConflict] and [corekv.ErrTxnConflict], allowing conflicts to be
+// detected without depending on the memory package.
+func (e *TxnConflictError) Unwrap() []error {
+	return []error{ErrTxnConflict, corekv.ErrTxnConflict}
+}
+
+// closedError is the type of [ErrClosed].
+//
+// It keeps the message that ErrClosed has always had, whilst also matching
+// [corekv.ErrDBClosed].
+type closedError struct{}
+
+func (e *closedError) Error() string {
+	return "datastore closed"
+}
+
+// Unwrap returns [corekv.ErrDBClosed].
+func (e *closedError) Unwrap() error {
+	return corekv.ErrDBClosed
+}
@@ -0,0 +1,142 @@
+package memory
+
+import (
+	"context"
+
+	"github.com/sourcenetwork/corekv"
+)
+
+var _ corekv.MultiReader = (*Datastore)(nil)
+var _ corekv.MultiReader = (*basicTxn)(nil)
+var _ corekv.MultiReader = (*Snapshot)(nil)
+
+// GetMany implements corekv.MultiReader.
+func (d *Datastore) GetMany(ctx context.Context, keys [][]byte) ([]corekv.GetResult, error) {
+	d.closeLk.RLock()
+	defer d.closeLk.RUnlock()
+	if d.closed {
+		return nil, ErrClosed
+	}
+
+	return getMany(ctx, keys, d.getter(d.getVersion()))
+}
+
+// HasMany implements corekv.MultiReader.
+func (d *Datastore) HasMany(ctx context.Context, keys [][]byte) ([]bool, error) {
+	d.closeLk.RLock()
+	defer d.closeLk.RUnlock()
+	if d.closed {
+		return nil, ErrClosed
+	}
+
+	return hasMany(ctx, keys, d.getter(d.getVersion()))
+}
+
+// GetMany implements corekv.MultiReader.
+func (t *basicTxn) GetMany(ctx context.Context, keys [][]byte) ([]corekv.GetResult, error) {
+	t.closeLk.RLock()
+	defer t.closeLk.RUnlock()
+	if t.closed {
+		return nil, ErrClosed
+	}
+	if t.discarded {
+		return nil, ErrTxnDiscarded
+	}
+
+	return getMany(ctx, keys, t.get)
+}
+
+// HasMany implements corekv.MultiReader.
+func (t *basicTxn) HasMany(ctx context.Context, keys [][]byte) ([]bool, error) {
+	t.closeLk.RLock()
+	defer t.closeLk.RUnlock()
+	if t.closed {
+		return nil, ErrClosed
+	}
+	if t.discarded {
+		return nil, ErrTxnDiscarded
+	}
+
+	return hasMany(ctx, keys, t.get)
+}
+
+// GetMany implements corekv.MultiReader.
+func (s *Snapshot) GetMany(ctx context.Context, keys [][]byte) ([]corekv.GetResult, error) {
+	s.closeLk.RLock()
+	defer s.closeLk.RUnlock()
+	if s.closed {
+		return nil, ErrSnapshotClosed
+	}
+	s.ds.closeLk.RLock()
+	defer s.ds.closeLk.RUnlock()
+	if s.ds.closed {
+		return nil, ErrClosed
+	}
+
+	return getMany(ctx, keys, s.ds.getter(s.version))
+}
+
+// HasMany implements corekv.MultiReader.
+func (s *Snapshot) HasMany(ctx context.Context, keys [][]byte) ([]bool, error) {
+	s.closeLk.RLock()
+	defer s.closeLk.RUnlock()
+	if s.closed {
+		return nil, ErrSnapshotClosed
+	}
+	s.ds.closeLk.RLock()
+	defer s.ds.closeLk.RUnlock()
+	if s.ds.closed {
+		return nil, ErrClosed
+	}
+
+	return hasMany(ctx, keys, s.ds.getter(s.version))
+}
+
+// getter returns a function that gets items from the datastore at the given version.
+func (d *Datastore) getter(version uint64) func(key []byte) dsItem {
+	return func(key []byte) dsItem {
+		return d.get(key, version)
+	}
+}
+
+func getMany(
+	ctx context.Context,
+	keys [][]byte,
+	get func(key []byte) dsItem,
+) ([]corekv.GetResult, error) {
+	results := make([]corekv.GetResult, len(keys))
+	for i, key := range keys {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		if len(key) == 0 {
+			return nil, corekv.ErrEmptyKey
+		}
+
+		result := get(key)
+		if result.key == nil || result.isDeleted {
+			continue
+		}
+		results[i] = corekv.GetResult{
+			Value: result.val,
+			Found: true,
+		}
+	}
+	return results, nil
+}
+
+func hasMany(ctx context.Context, keys [][]byte, get func(key []byte) dsItem) ([]bool, error) {
+	results := make([]bool, len(keys))
+	for i, key := range keys {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		if len(key) == 0 {
+			return nil, corekv.ErrEmptyKey
+		}
+
+		result := get(key)
+		results[i] = result.key != nil && !result.isDeleted
+	}
+	return results, nil
+}
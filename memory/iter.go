@@ -3,6 +3,8 @@ package memory
 import (
 	"bytes"
 	"context"
+	"math"
+	"time"
 
 	"github.com/sourcenetwork/corekv"
 
@@ -10,50 +12,81 @@ import (
 )
 
 type iterator struct {
-	db      *Datastore
+	// The context the iterator was created with, moving the iterator will fail once
+	// it is cancelled.
+	ctx context.Context
+
+	// Returns the current time, used to expire items.
+	now func() time.Time
+
+	// The version at which items are read, items with a version greater than this
+	// will not be yielded.
 	version uint64
 	it      btree.IterG[dsItem]
 
-	// The key at which this iterator begins.
-	//
-	// This is inclusive only if `mayExactlyMatchStart` is true.
+	// The key at which this iterator begins, inclusive.
 	start []byte
 
-	// The key at which this iterator ends, inclusive.
+	// The key at which this iterator ends, exclusive.
 	end []byte
 
 	// If true, the iterator will iterate in reverse order, from the largest
 	// key to the smallest.
 	reverse bool
 
+	// If true, [Value] will always return nil, matching the behaviour of the
+	// badger store.
+	keysOnly bool
+
+	// If true, deleted items will be yielded by the iterator instead of being
+	// skipped over.
+	//
+	// This allows the iterator to be used as a source of pending operations by
+	// transaction iterators, where deletions need to mask underlying values.
+	tombstones bool
+
 	// reset is a mutatuble property that indicates whether the iterator should be
 	// returned to the beginning on the next [Next] call.
 	reset bool
+
+	// hasRaw is true if the underlying btree iterator is positioned on an item
+	// that has not yet been consumed by this iterator.
+	//
+	// The underlying btree iterator must not be moved in the iteration direction
+	// if this is false, see `TestBTreePrevBug`.
+	hasRaw bool
+
+	// The item at the current iterator location.
+	item dsItem
+
+	// err is set if the iterator was created with invalid options, and will be
+	// returned on any attempt to move the iterator.
+	err error
 }
 
 var _ corekv.Iterator = (*iterator)(nil)
 
-func newPrefixIter(db *Datastore, prefix []byte, reverse bool, version uint64) *iterator {
-	return &iterator{
-		db:      db,
-		version: version,
-		it:      db.values.Iter(),
-		start:   prefix,
-		end:     bytesPrefixEnd(prefix),
-		reverse: reverse,
-		reset:   true,
-	}
-}
+// newIter returns a new iterator over the given values using the given options.
+func newIter(
+	ctx context.Context,
+	values *btree.BTreeG[dsItem],
+	opts corekv.IterOptions,
+	version uint64,
+	now func() time.Time,
+) *iterator {
+	start, end, err := opts.Bounds()
 
-func newRangeIter(db *Datastore, start, end []byte, reverse bool, version uint64) *iterator {
 	return &iterator{
-		db:      db,
-		version: version,
-		it:      db.values.Iter(),
-		start:   start,
-		end:     end,
-		reverse: reverse,
-		reset:   true,
+		ctx:      ctx,
+		now:      now,
+		version:  version,
+		it:       values.Iter(),
+		start:    start,
+		end:      end,
+		reverse:  opts.Reverse,
+		keysOnly: opts.KeysOnly,
+		reset:    true,
+		err:      err,
 	}
 }
 
@@ -66,80 +99,128 @@ func (iter *iterator) Reset() {
 func (iter *iterator) restart() (bool, error) {
 	iter.reset = false
 
-	if len(iter.end) > 0 && iter.reverse {
-		return iter.Seek(iter.end)
-	} else if len(iter.start) > 0 && !iter.reverse {
-		return iter.Seek(iter.start)
+	if iter.reverse {
+		if len(iter.end) > 0 {
+			return iter.Seek(iter.end)
+		}
+		iter.hasRaw = iter.it.Last()
 	} else {
-		var hasItem bool
-		if iter.reverse {
-			hasItem = iter.it.Last()
-			// We don't need to bother loading the latest item in reverse, as the Last item
-			// will be of the latest version anyway.
-		} else {
-			hasItem = iter.it.First()
-			iter.loadLatestItem()
+		if len(iter.start) > 0 {
+			return iter.Seek(iter.start)
 		}
+		iter.hasRaw = iter.it.First()
+	}
+
+	return iter.settled()
+}
 
-		if !hasItem {
-			return false, nil
+// settle moves the underlying btree iterator forward (in the iteration direction) until it has
+// consumed the next yieldable key, storing the yieldable version of that key in `item`.
+//
+// Once settled, the underlying btree iterator will be positioned on the first (in the iteration
+// direction) item of the next key.
+//
+// It returns true if a yieldable key was found, otherwise false.  If the iterator's context
+// is cancelled whilst settling, false will be returned.
+func (iter *iterator) settle() bool {
+	for iter.hasRaw {
+		if iter.ctx.Err() != nil {
+			break
 		}
 
-		if !iter.valid() {
-			return iter.Next()
+		key := iter.it.Item().key
+		if iter.beyondEnd(key) {
+			break
+		}
+
+		item, found := iter.scanKey(key)
+		if !found || !iter.inRange(key) {
+			continue
+		}
+		item = expireItem(item, iter.now())
+		if item.isDeleted && !iter.tombstones {
+			continue
 		}
 
+		iter.item = item
+		return true
+	}
+
+	iter.hasRaw = false
+	iter.item = dsItem{}
+	return false
+}
+
+// settled settles the iterator, returning the context's error if it was cancelled before
+// a yieldable key was found.
+func (iter *iterator) settled() (bool, error) {
+	if iter.settle() {
 		return true, nil
 	}
+	return false, iter.ctx.Err()
+}
+
+// scanKey consumes all the versions of the given key from the underlying btree iterator,
+// returning the latest version of it readable by this iterator.
+func (iter *iterator) scanKey(key []byte) (dsItem, bool) {
+	var result dsItem
+	var found bool
+	for {
+		item := iter.it.Item()
+		if !bytes.Equal(key, item.key) {
+			return result, found
+		}
+
+		if item.version <= iter.version && (!found || item.version > result.version) {
+			result = item
+			found = true
+		}
 
+		iter.hasRaw = iter.move()
+		if !iter.hasRaw {
+			return result, found
+		}
+	}
 }
 
-func (iter *iterator) valid() bool {
-	if len(iter.it.Item().key) == 0 {
-		return false
+// beyondEnd returns true if the given key, and all keys following it in the
+// direction of iteration, are outside of the iterator's bounds.
+func (iter *iterator) beyondEnd(key []byte) bool {
+	if iter.reverse {
+		return len(iter.start) > 0 && lt(key, iter.start)
 	}
+	return len(iter.end) > 0 && !lt(key, iter.end)
+}
 
-	if iter.it.Item().isDeleted {
+// inRange returns true if the given key lies within the iterator's bounds.
+func (iter *iterator) inRange(key []byte) bool {
+	if len(key) == 0 {
 		return false
 	}
 
-	if len(iter.end) > 0 && !lt(iter.it.Item().key, iter.end) {
+	if len(iter.end) > 0 && !lt(key, iter.end) {
 		return false
 	}
 
-	return gte(iter.it.Item().key, iter.start)
+	return gte(key, iter.start)
 }
 
 func (iter *iterator) Next() (bool, error) {
-	if iter.reset {
-		return iter.restart()
-	}
-
-	previousItem := iter.it.Item()
-	var hasItem bool
-	for iter.next() {
-		// Scan through until we reach the next key.
-		// It doesn't matter if it is deleted or not.
-		if !bytes.Equal(previousItem.key, iter.it.Item().key) {
-			hasItem = true
-			break
-		}
+	if iter.err != nil {
+		return false, iter.err
 	}
-
-	if !hasItem {
-		return false, nil
+	if err := iter.ctx.Err(); err != nil {
+		return false, err
 	}
-
-	iter.loadLatestItem()
-
-	if iter.it.Item().isDeleted {
-		return iter.Next()
+	if iter.reset {
+		return iter.restart()
 	}
 
-	return iter.valid(), nil
+	return iter.settled()
 }
 
-func (iter *iterator) next() bool {
+// move moves the underlying btree iterator one item in the iteration direction.
+func (iter *iterator) move() bool {
 	if iter.reverse {
 		// WARNING: There is a bug in `Prev` that can cause unexpected behaviour
 		// when attempting to iterate beyond the end of the iterator.
@@ -153,29 +234,28 @@ func (iter *iterator) next() bool {
 }
 
 func (iter *iterator) Key() []byte {
-	return iter.it.Item().key
+	return iter.item.key
 }
 
 func (iter *iterator) Value() ([]byte, error) {
-	return iter.it.Item().val, nil
+	if iter.keysOnly {
+		return nil, nil
+	}
+	return iter.item.val, nil
 }
 
 func (iter *iterator) Seek(key []byte) (bool, error) {
+	if iter.err != nil {
+		return false, iter.err
+	}
+	if err := iter.ctx.Err(); err != nil {
+		return false, err
+	}
+
 	// Clear the reset property, else if Next was call following Seek,
 	// Next may incorrectly return to the beginning.
 	iter.reset = false
 
-	// get the correct initial version for the seek
-	// if there exists an exact match in keys, use the latest version
-	// of that key, otherwise, use the provided DB version
-	// TODO this could use some "peek" mechanic instead of a full lookup
-	version := iter.version
-	result := iter.db.get(key, version)
-	if result.key != nil && !result.isDeleted {
-		version = result.version
-	}
-
-	var hasItem bool
 	if iter.reverse {
 		// Unfortunately the BTree iterator doesn't provide a reversed seek, so we have to
 		// do a bit of work ourselves here if iterating in reverse.
@@ -189,25 +269,14 @@ func (iter *iterator) Seek(key []byte) (bool, error) {
 			target = key
 		}
 
-		hasItem = iter.it.Seek(dsItem{key: target, version: version})
-		if hasItem {
-			if !bytes.Equal(target, iter.it.Item().key) {
-				// If the BTree iterator `Seek` finds an item, it must be equal or greater than
-				// our upper bound.  The previous item key must then be less than our upper bound
-				// so if it is not equal we must look back once.
-				hasItem = iter.it.Prev()
-			}
-		}
-
-		if !hasItem {
-			// If no items were found above or on the upper bound, we can move to the end of the
+		// Seek to the first item after all versions of the target, the item before it will
+		// then be the latest version of the largest key smaller than or equal to the target.
+		if iter.it.Seek(dsItem{key: target, version: math.MaxUint64}) {
+			iter.hasRaw = iter.it.Prev()
+		} else {
+			// If no items were found above the target, we can move to the end of the
 			// BTree.
-			hasItem = iter.it.Last()
-		}
-
-		if !hasItem {
-			// If there are no items found at this point, it means the store is empty.
-			return false, nil
+			iter.hasRaw = iter.it.Last()
 		}
 	} else {
 		var target []byte
@@ -219,20 +288,10 @@ func (iter *iterator) Seek(key []byte) (bool, error) {
 			target = key
 		}
 
-		hasItem = iter.it.Seek(dsItem{key: target, version: version})
-	}
-
-	if !hasItem {
-		return false, nil
-	}
-
-	iter.loadLatestItem()
-
-	if !iter.valid() {
-		return iter.Next()
+		iter.hasRaw = iter.it.Seek(dsItem{key: target})
 	}
 
-	return true, nil
+	return iter.settled()
 }
 
 func (iter *iterator) Close(ctx context.Context) error {
@@ -240,32 +299,6 @@ func (iter *iterator) Close(ctx context.Context) error {
 	return nil
 }
 
-func (iter *iterator) loadLatestItem() {
-	previousItem := iter.it.Item()
-	for iter.it.Next() {
-		// Scan through until we reach the next key.
-		// It doesn't matter if it is deleted or not.
-		if !bytes.Equal(previousItem.key, iter.it.Item().key) {
-			iter.it.Prev()
-			break
-		}
-	}
-}
-
-func bytesPrefixEnd(b []byte) []byte {
-	end := make([]byte, len(b))
-	copy(end, b)
-	for i := len(end) - 1; i >= 0; i-- {
-		end[i] = end[i] + 1
-		if end[i] != 0 {
-			return end[:i+1]
-		}
-	}
-	// This statement will only be reached if the key is already a
-	// maximal byte string (i.e. already \xff...).
-	return b
-}
-
 // greater than or equal to (a >= b)
 func gte(a, b []byte) bool {
 	return bytes.Compare(a, b) > -1
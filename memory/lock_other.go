@@ -0,0 +1,16 @@
+//go:build !(linux || darwin || freebsd || netbsd || openbsd || dragonfly)
+
+package memory
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// lockDir creates the lock file in the directory at the given path.
+//
+// File locks are not supported on this platform, so the directory is not locked and
+// opening it from more than one datastore is not detected.
+func lockDir(dir string) (*os.File, error) {
+	return os.OpenFile(filepath.Join(dir, lockFileName), os.O_RDWR|os.O_CREATE, 0o644)
+}
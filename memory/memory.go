@@ -4,11 +4,13 @@ import (
 	"bytes"
 	"context"
 	"errors"
+	"sort"
 	"sync"
 	"sync/atomic"
 	"time"
 
 	"github.com/sourcenetwork/corekv"
+	"github.com/sourcenetwork/corekv/internal/watch"
 
 	"github.com/tidwall/btree"
 )
@@ -16,8 +18,10 @@ import (
 type dsTxn struct {
 	dsVersion  uint64
 	txnVersion uint64
-	expiresAt  time.Time
-	txn        *basicTxn
+	// Uniquely identifies the transaction, as many transactions may share the same versions.
+	id        uint64
+	expiresAt time.Time
+	txn       *basicTxn
 }
 
 func byDSVersion(a, b dsTxn) bool {
@@ -26,6 +30,8 @@ func byDSVersion(a, b dsTxn) bool {
 		return true
 	case a.dsVersion == b.dsVersion && a.txnVersion < b.txnVersion:
 		return true
+	case a.dsVersion == b.dsVersion && a.txnVersion == b.txnVersion && a.id < b.id:
+		return true
 	default:
 		return false
 	}
@@ -36,7 +42,17 @@ type dsItem struct {
 	version   uint64
 	val       []byte
 	isDeleted bool
-	isGet     bool
+	// The time at which this item expires, zero if the item does not expire.
+	expiresAt time.Time
+}
+
+// expireItem returns the given item marked as deleted if it has expired at the given time,
+// otherwise the item is returned unchanged.
+func expireItem(item dsItem, now time.Time) dsItem {
+	if !item.expiresAt.IsZero() && !now.Before(item.expiresAt) {
+		item.isDeleted = true
+	}
+	return item
 }
 
 func byKeys(a, b dsItem) bool {
@@ -56,10 +72,41 @@ func byVersion(a, b dsItem) bool {
 
 // Datastore uses a btree for internal storage.
 type Datastore struct {
+	opts options
+
 	// Latest committed version.
 	version     *uint64
 	values      *btree.BTreeG[dsItem]
 	inFlightTxn *btree.BTreeG[dsTxn]
+	// The id of the most recently created transaction.
+	lastTxnID uint64
+
+	// The number of open snapshots pinned to each version.
+	snapshots map[uint64]int
+	// Versions older than this may have been purged and can no longer be read
+	// by new snapshots.
+	purgedVersion uint64
+	snapshotLk    sync.Mutex
+
+	// The times at which recent versions were committed, recorded only if versions
+	// are to be retained for a duration.
+	commitTimes   []versionTime
+	commitTimesLk sync.Mutex
+
+	// Serializes purges.
+	purgeLk sync.Mutex
+
+	// The keys written by each recent commit, in version order, allowing the ranges read by
+	// transactions to be checked for conflicts without scanning the values.  Holds every
+	// commit with a version greater than commitLogFrom, and is guarded by the commitLk.
+	commitLog     []committedKeys
+	commitLogFrom uint64
+
+	watchers map[*watch.Watcher]struct{}
+	watchLk  sync.Mutex
+
+	// Durably records committed changes, nil if the datastore is not persisted.
+	persister *persister
 
 	closing  chan struct{}
 	closed   bool
@@ -67,45 +114,83 @@ type Datastore struct {
 	commitLk sync.Mutex
 }
 
-var _ corekv.Store = (*Datastore)(nil)
+var _ corekv.TxnStore = (*Datastore)(nil)
+var _ corekv.Batchable = (*Datastore)(nil)
 
-// var _ corekv.Batchable = (*Datastore)(nil)
-// var _ corekv.TxnStore = (*Datastore)(nil)
+// NewDatastore constructs an empty Datastore configured with the given options.
+//
+// The datastore is held only in memory, use [Open] for a datastore that persists its changes.
+func NewDatastore(ctx context.Context, opts ...Option) *Datastore {
+	d := newDatastore(opts)
+	d.start(ctx)
+	return d
+}
 
-// NewDatastore constructs an empty Datastore.
-func NewDatastore(ctx context.Context) *Datastore {
+func newDatastore(opts []Option) *Datastore {
+	o := newOptions(opts)
 	v := uint64(0)
-	d := &Datastore{
+	return &Datastore{
+		opts:        o,
 		version:     &v,
-		values:      btree.NewBTreeG(byKeys),
+		values:      btree.NewBTreeGOptions(byKeys, btree.Options{Degree: o.btreeDegree}),
 		inFlightTxn: btree.NewBTreeG(byDSVersion),
+		snapshots:   map[uint64]int{},
+		watchers:    map[*watch.Watcher]struct{}{},
 		closing:     make(chan struct{}),
 	}
+}
+
+// start starts the background processes of the datastore.
+func (d *Datastore) start(ctx context.Context) {
+	if d.opts.closeOnContextDone {
+		go d.handleContextDone(ctx)
+	} else {
+		// The datastore outlives the context, so background processes must only stop
+		// once the datastore is closed.
+		ctx = context.Background()
+	}
+
 	go d.purgeOldVersions(ctx)
-	go d.handleContextDone(ctx)
-	return d
+	if d.persister != nil {
+		go d.compactInBackground(ctx)
+	}
+}
+
+// now returns the current time according to the datastore's clock.
+func (d *Datastore) now() time.Time {
+	return d.opts.clock()
 }
 
 func (d *Datastore) getVersion() uint64 {
 	return atomic.LoadUint64(d.version)
 }
 
+// Version returns the latest committed version of the datastore.
+func (d *Datastore) Version() uint64 {
+	return d.getVersion()
+}
+
 func (d *Datastore) nextVersion() uint64 {
 	return atomic.AddUint64(d.version, 1)
 }
 
-// Batch return a corekv.Batch datastore based on Datastore.
-// func (d *Datastore) Batch(ctx context.Context) (corekv.Batch, error) {
-// 	return d.newBasicBatch(), nil
-// }
+// Batch implements corekv.Batchable.
+func (d *Datastore) Batch(ctx context.Context) (corekv.Batch, error) {
+	d.closeLk.RLock()
+	defer d.closeLk.RUnlock()
+	if d.closed {
+		return nil, ErrClosed
+	}
+	return d.newBasicBatch(), nil
+}
 
-// // newBasicBatch returns a corekv.Batch datastore.
-// func (d *Datastore) newBasicBatch() corekv.Batch {
-// 	return &basicBatch{
-// 		ops: make(map[[]byte]op),
-// 		ds:  d,
-// 	}
-// }
+// newBasicBatch returns a new, empty, basicBatch.
+func (d *Datastore) newBasicBatch() *basicBatch {
+	return &basicBatch{
+		ops: btree.NewBTreeG(byKeys),
+		ds:  d,
+	}
+}
 
 func (d *Datastore) Close() error {
 	d.closeLk.Lock()
@@ -124,7 +209,16 @@ func (d *Datastore) Close() error {
 	}
 	iter.Release()
 
-	return nil
+	if d.persister == nil {
+		return nil
+	}
+
+	d.commitLk.Lock()
+	defer d.commitLk.Unlock()
+
+	// Compacting on close allows the datastore to be quickly reopened.
+	err := d.compact(context.Background())
+	return errors.Join(err, d.persister.close())
 }
 
 // Delete implements corekv.Store
@@ -154,7 +248,7 @@ func (d *Datastore) get(key []byte, version uint64) dsItem {
 		// We only care about the last version so we stop iterating right away by returning false.
 		return false
 	})
-	return result
+	return expireItem(result, d.now())
 }
 
 // Get implements corekv.Store.
@@ -189,15 +283,25 @@ func (d *Datastore) Has(ctx context.Context, key []byte) (exists bool, err error
 	return result.key != nil && !result.isDeleted, nil
 }
 
-// NewTransaction return a corekv.Txn datastore based on Datastore.
-// func (d *Datastore) NewTxn(ctx context.Context, readOnly bool) (corekv.Txn, error) {
-// 	d.closeLk.RLock()
-// 	defer d.closeLk.RUnlock()
-// 	if d.closed {
-// 		return nil, ErrClosed
-// 	}
-// 	return d.newTransaction(readOnly), nil
-// }
+// NewTxn implements corekv.TxnStore.
+//
+// If the datastore has been closed the returned transaction will also be closed,
+// and will return an error when used.
+func (d *Datastore) NewTxn(readOnly bool) corekv.Txn {
+	d.closeLk.RLock()
+	defer d.closeLk.RUnlock()
+	if d.closed {
+		v := d.getVersion()
+		return &basicTxn{
+			ops:       btree.NewBTreeG(byKeys),
+			ds:        d,
+			readOnly:  readOnly,
+			dsVersion: &v,
+			closed:    true,
+		}
+	}
+	return d.newTransaction(readOnly)
+}
 
 // newTransaction returns a corekv.Txn datastore.
 //
@@ -214,7 +318,15 @@ func (d *Datastore) newTransaction(readOnly bool) *basicTxn {
 		dsVersion: &v,
 	}
 
-	d.inFlightTxn.Set(dsTxn{v, v + 1, time.Now().Add(1 * time.Hour), txn})
+	txn.id = atomic.AddUint64(&d.lastTxnID, 1)
+
+	d.inFlightTxn.Set(dsTxn{
+		dsVersion:  v,
+		txnVersion: v + 1,
+		id:         txn.id,
+		expiresAt:  d.now().Add(d.opts.txnExpiry),
+		txn:        txn,
+	})
 	return txn
 }
 
@@ -239,71 +351,7 @@ func (d *Datastore) Set(ctx context.Context, key []byte, value []byte) (err erro
 }
 
 func (d *Datastore) Iterator(ctx context.Context, opts corekv.IterOptions) corekv.Iterator {
-	if opts.Prefix != nil {
-		return newPrefixIter(d, opts.Prefix, opts.Reverse, d.getVersion())
-	}
-	return newRangeIter(d, opts.Start, opts.End, opts.Reverse, d.getVersion())
-}
-
-// purgeOldVersions will execute the purge once a day or when explicitly requested.
-func (d *Datastore) purgeOldVersions(ctx context.Context) {
-	dbStartTime := time.Now()
-	nextCompression := time.Date(dbStartTime.Year(), dbStartTime.Month(), dbStartTime.Day()+1,
-		0, 0, 0, 0, dbStartTime.Location())
-
-	for {
-		select {
-		case <-ctx.Done():
-			return
-		case <-d.closing:
-			return
-		case <-time.After(time.Until(nextCompression)):
-			d.executePurge()
-			now := time.Now()
-			nextCompression = time.Date(now.Year(), now.Month(), now.Day()+1, 0, 0, 0, 0, now.Location())
-		}
-	}
-}
-
-func (d *Datastore) executePurge() {
-	// purging bellow this version
-	v := d.getVersion()
-	if dsTxn, hasMin := d.inFlightTxn.Min(); hasMin {
-		v = dsTxn.dsVersion
-	}
-
-	for {
-		itemsToDelete := []dsItem{}
-		iter := d.values.Iter()
-		iter.Next()
-		item := iter.Item()
-		// fast forward to last inserted version and delete versions before it
-		total := 0
-		for iter.Next() {
-			if iter.Item().version > v {
-				continue
-			}
-			if bytes.Equal(item.key, iter.Item().key) {
-				itemsToDelete = append(itemsToDelete, item)
-				total++
-			}
-			item = iter.Item()
-			// we don't want to delete more than 1000 items at a time
-			// to prevent loading too much into memory
-			if total >= 1000 {
-				break
-			}
-		}
-		iter.Release()
-
-		if total == 0 {
-			return
-		}
-
-		for _, i := range itemsToDelete {
-			d.values.Delete(i)
-		}
-	}
+	return corekv.Paginate(newIter(ctx, d.values, opts, d.getVersion(), d.now), opts)
 }
 
 func (d *Datastore) handleContextDone(ctx context.Context) {
@@ -314,7 +362,9 @@ func (d *Datastore) handleContextDone(ctx context.Context) {
 // commit commits the given transaction to the datastore.
 //
 // WARNING: This is a notable bottleneck, as commits can only be commited one at a time (handled internally).
-// This is to ensure correct, threadsafe, mututation of the datastore version.
+// This is to ensure correct, threadsafe, mututation of the datastore version.  The time spent holding the
+// lock is kept proportional to the size of the transaction, and the commits made since it was created, but
+// finer grained locking of commits is not currently supported.
 func (d *Datastore) commit(t *basicTxn) error {
 	d.commitLk.Lock()
 	defer d.commitLk.Unlock()
@@ -327,26 +377,148 @@ func (d *Datastore) commit(t *basicTxn) error {
 		return err
 	}
 
-	iter := t.ops.Iter()
-	v := t.ds.nextVersion()
+	return d.write(t.ops)
+}
+
+// committedKeys holds the keys, in lexographical order, written by the commit of a version.
+type committedKeys struct {
+	version uint64
+	keys    [][]byte
+}
+
+// write writes the given operations to the datastore, all at the same new version.
+//
+// If the datastore is persisted, the operations are durably recorded before being applied,
+// and will not be applied if this fails.
+//
+// The commitLk must be held when calling this function.
+func (d *Datastore) write(ops *btree.BTreeG[dsItem]) error {
+	iter := ops.Iter()
+	v := d.getVersion() + 1
+	items := make([]dsItem, 0, ops.Len())
 	for iter.Next() {
-		if iter.Item().isGet {
-			continue
-		}
 		item := iter.Item()
 		item.version = v
-		t.ds.values.Set(item)
+		items = append(items, item)
 	}
 	iter.Release()
+
+	if d.persister != nil {
+		err := d.persister.append(v, items)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, item := range items {
+		d.values.Set(item)
+	}
+	d.nextVersion()
+
+	keys := make([][]byte, len(items))
+	for i, item := range items {
+		keys[i] = item.key
+	}
+	d.commitLog = append(d.commitLog, committedKeys{version: v, keys: keys})
+	d.pruneCommitLog()
+
+	if d.opts.retainDuration > 0 {
+		now := d.now()
+		d.commitTimesLk.Lock()
+		d.commitTimes = append(d.commitTimes, versionTime{version: v, committedAt: now})
+		// The commit times are trimmed here, as well as when purging, so that they do not grow
+		// without bound should purges be infrequent or disabled.
+		d.trimCommitTimes(now.Add(-d.opts.retainDuration))
+		d.commitTimesLk.Unlock()
+	}
+
+	d.publish(items)
+
+	if d.persister != nil {
+		d.persister.signalCompaction()
+	}
 	return nil
 }
 
+// writtenSince returns the first key within the given range that has been written to at a
+// version greater than since, and not greater than until.
+//
+// The commits since the given version are found in the commit log, so the cost of the check
+// depends on the number of keys they wrote and not on the size of the datastore.  Should
+// the log no longer hold them, for example if the transaction reading the range expired,
+// the values within the range are scanned instead.
+//
+// The commitLk must be held when calling this function.
+func (d *Datastore) writtenSince(r keyRange, since, until uint64) ([]byte, bool) {
+	if since < d.commitLogFrom {
+		return d.scanWrittenSince(r, since, until)
+	}
+
+	i := sort.Search(len(d.commitLog), func(i int) bool {
+		return d.commitLog[i].version > since
+	})
+	for ; i < len(d.commitLog) && d.commitLog[i].version <= until; i++ {
+		keys := d.commitLog[i].keys
+
+		// The keys are sorted, so only the first key not before the start of the range
+		// may be within it.
+		j := 0
+		if r.start != nil {
+			j = sort.Search(len(keys), func(j int) bool {
+				return !lt(keys[j], r.start)
+			})
+		}
+		if j < len(keys) && (r.end == nil || lt(keys[j], r.end)) {
+			return keys[j], true
+		}
+	}
+	return nil, false
+}
+
+// scanWrittenSince returns the first key within the given range that has been written to at
+// a version greater than since, and not greater than until, by scanning the values within
+// the range.
+func (d *Datastore) scanWrittenSince(r keyRange, since, until uint64) ([]byte, bool) {
+	var result []byte
+	d.values.Ascend(dsItem{key: r.start}, func(item dsItem) bool {
+		if r.end != nil && !lt(item.key, r.end) {
+			return false
+		}
+		if item.version > since && item.version <= until {
+			result = item.key
+			return false
+		}
+		return true
+	})
+	return result, result != nil
+}
+
+// pruneCommitLog removes the commits that can no longer conflict with any in-flight
+// transaction from the commit log.
+//
+// The commitLk must be held when calling this function.
+func (d *Datastore) pruneCommitLog() {
+	oldest := d.getVersion()
+	if txn, ok := d.inFlightTxn.Min(); ok && txn.dsVersion < oldest {
+		oldest = txn.dsVersion
+	}
+	if oldest <= d.commitLogFrom {
+		return
+	}
+
+	i := sort.Search(len(d.commitLog), func(i int) bool {
+		return d.commitLog[i].version > oldest
+	})
+	d.commitLog = d.commitLog[i:]
+	d.commitLogFrom = oldest
+}
+
 func (d *Datastore) clearOldInFlightTxn() {
 	if d.inFlightTxn.Height() == 0 {
 		return
 	}
 
-	now := time.Now()
+	now := d.now()
 	for {
 		itemsToDelete := []dsTxn{}
 		iter := d.inFlightTxn.Iter()
@@ -18,6 +18,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/sourcenetwork/corekv"
 	"github.com/stretchr/testify/require"
 )
 
@@ -79,7 +80,7 @@ func TestPurgeBatching(t *testing.T) {
 		}
 	}
 
-	s.executePurge()
+	s.executePurge(ctx)
 
 	resp, err := s.Get(ctx, []byte("test"))
 	require.NoError(t, err)
@@ -102,7 +103,7 @@ func TestPurgeWithOlderInFlightTxn(t *testing.T) {
 	err := s.Set(ctx, testKey4, testValue4)
 	require.NoError(t, err)
 
-	s.executePurge()
+	s.executePurge(ctx)
 }
 
 func TestClearOldFlightTransactions(t *testing.T) {
@@ -123,3 +124,171 @@ func TestClearOldFlightTransactions(t *testing.T) {
 
 	require.Equal(t, 0, s.inFlightTxn.Len())
 }
+
+func TestBatchCommitsAtSingleVersion(t *testing.T) {
+	ctx := context.Background()
+	s := NewDatastore(ctx)
+
+	batch, err := s.Batch(ctx)
+	require.NoError(t, err)
+
+	for i := 1; i <= 100; i++ {
+		err := batch.Set(ctx, []byte(fmt.Sprintf("testKey%d", i)), []byte(fmt.Sprintf("this is a test value %d", i)))
+		require.NoError(t, err)
+	}
+
+	err = batch.Commit(ctx)
+	require.NoError(t, err)
+
+	require.Equal(t, uint64(1), s.getVersion())
+	require.Equal(t, 100, s.values.Len())
+}
+
+func TestConcurrentCompareAndSwap(t *testing.T) {
+	ctx := context.Background()
+	s := NewDatastore(ctx)
+
+	err := s.Set(ctx, testKey1, []byte("0"))
+	require.NoError(t, err)
+
+	wg := &sync.WaitGroup{}
+
+	for i := 1; i <= 100; i++ {
+		wg.Add(1)
+		go func(wg *sync.WaitGroup) {
+			defer wg.Done()
+			for {
+				resp, err := s.Get(ctx, testKey1)
+				require.NoError(t, err)
+
+				val, err := strconv.Atoi(string(resp))
+				require.NoError(t, err)
+
+				swapped, err := s.CompareAndSwap(ctx, testKey1, resp, []byte(strconv.Itoa(val+1)))
+				require.NoError(t, err)
+				if swapped {
+					return
+				}
+			}
+		}(wg)
+	}
+	wg.Wait()
+
+	resp, err := s.Get(ctx, testKey1)
+	require.NoError(t, err)
+	require.Equal(t, []byte("100"), resp)
+}
+
+func TestTxnConflictError(t *testing.T) {
+	ctx := context.Background()
+	s := NewDatastore(ctx)
+
+	txn := s.NewTxn(false)
+	_, err := txn.Has(ctx, testKey1)
+	require.NoError(t, err)
+	err = txn.Set(ctx, testKey2, testValue2)
+	require.NoError(t, err)
+
+	err = s.Set(ctx, testKey1, testValue1)
+	require.NoError(t, err)
+
+	err = txn.Commit(ctx)
+	require.ErrorIs(t, err, ErrTxnConflict)
+	require.ErrorIs(t, err, corekv.ErrTxnConflict)
+
+	var conflictErr *TxnConflictError
+	require.ErrorAs(t, err, &conflictErr)
+	require.Equal(t, testKey1, conflictErr.Key)
+}
+
+func TestTxnConflict_RangeRead(t *testing.T) {
+	ctx := context.Background()
+	s := NewDatastore(ctx)
+
+	txn := s.NewTxn(false)
+	it := txn.Iterator(ctx, corekv.IterOptions{Start: testKey1, End: []byte("testKey3")})
+	_, err := it.Next()
+	require.NoError(t, err)
+	err = it.Close(ctx)
+	require.NoError(t, err)
+
+	err = s.Set(ctx, testKey4, testValue4)
+	require.NoError(t, err)
+	err = s.Set(ctx, testKey2, testValue2)
+	require.NoError(t, err)
+
+	err = txn.Set(ctx, testKey1, testValue1)
+	require.NoError(t, err)
+	err = txn.Commit(ctx)
+
+	var conflictErr *TxnConflictError
+	require.ErrorAs(t, err, &conflictErr)
+	require.Equal(t, testKey2, conflictErr.Key)
+}
+
+func TestTxnConflict_ExpiredTxnRangeRead(t *testing.T) {
+	ctx := context.Background()
+	clock := newTestClock()
+	s := NewDatastore(ctx, WithClock(clock.Now), WithTxnExpiry(time.Minute))
+
+	txn := s.NewTxn(false)
+	it := txn.Iterator(ctx, corekv.IterOptions{Start: testKey1, End: []byte("testKey3")})
+	_, err := it.Next()
+	require.NoError(t, err)
+	err = it.Close(ctx)
+	require.NoError(t, err)
+
+	err = s.Set(ctx, testKey2, testValue2)
+	require.NoError(t, err)
+
+	// Once the transaction has expired, the commits since it was created are no longer
+	// held for it, and the values must be scanned to detect the conflict.
+	clock.Advance(2 * time.Minute)
+	for i := 0; i < 2; i++ {
+		// Expired transactions are cleared once a write completes, so a second write is
+		// required to prune the log.
+		err = s.Set(ctx, testKey4, testValue4)
+		require.NoError(t, err)
+	}
+	s.commitLk.Lock()
+	logFrom := s.commitLogFrom
+	s.commitLk.Unlock()
+	require.Greater(t, logFrom, txn.(*basicTxn).getDSVersion())
+
+	err = txn.Set(ctx, testKey1, testValue1)
+	require.NoError(t, err)
+	err = txn.Commit(ctx)
+
+	var conflictErr *TxnConflictError
+	require.ErrorAs(t, err, &conflictErr)
+	require.Equal(t, testKey2, conflictErr.Key)
+}
+
+func TestCommitLog_Pruned(t *testing.T) {
+	ctx := context.Background()
+	s := NewDatastore(ctx)
+
+	for i := 0; i < 100; i++ {
+		err := s.Set(ctx, testKey1, testValue1)
+		require.NoError(t, err)
+	}
+
+	s.commitLk.Lock()
+	defer s.commitLk.Unlock()
+	// Only the commit of the most recent write, whose transaction was still in-flight
+	// when the log was last pruned, may remain.
+	require.LessOrEqual(t, len(s.commitLog), 1)
+}
+
+func TestErrClosed(t *testing.T) {
+	ctx := context.Background()
+	s := NewDatastore(ctx)
+
+	err := s.Close()
+	require.NoError(t, err)
+
+	_, err = s.Get(ctx, testKey1)
+	require.ErrorIs(t, err, ErrClosed)
+	require.ErrorIs(t, err, corekv.ErrDBClosed)
+	require.EqualError(t, err, "datastore closed")
+}
@@ -0,0 +1,141 @@
+package memory
+
+import (
+	"time"
+
+	"github.com/sourcenetwork/corekv/internal/watch"
+)
+
+// Option configures a [Datastore].
+type Option func(*options)
+
+type options struct {
+	// The duration after which in-flight transactions no longer prevent the versions they
+	// read from being purged.
+	txnExpiry time.Duration
+
+	// The degree of the btree holding the datastore's values, zero for the btree default.
+	btreeDegree int
+
+	// Returns the current time.
+	clock func() time.Time
+
+	// If true, the datastore will be closed when the context it was created with is done.
+	closeOnContextDone bool
+
+	// The interval between automatic purges, zero if purges should run daily at local
+	// midnight, and negative if automatic purges are disabled.
+	purgeInterval time.Duration
+
+	// The number of latest versions that will not be purged, zero if unlimited.
+	retainVersions uint64
+
+	// The duration for which committed versions will not be purged, zero if unlimited.
+	retainDuration time.Duration
+
+	// The maximum number of commits whose events may be queued for delivery to each watcher.
+	watchQueueSize int
+
+	// If set, called with the outcome of each automatic purge.
+	onPurged func(PurgeStats)
+
+	// If set, called with the outcome of each automatic compaction.
+	onCompacted func(error)
+}
+
+func newOptions(opts []Option) options {
+	o := options{
+		txnExpiry:          time.Hour,
+		clock:              time.Now,
+		closeOnContextDone: true,
+		watchQueueSize:     watch.DefaultQueueSize,
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// WithPurgeInterval sets the interval between automatic purges of old versions.
+//
+// By default old versions are purged daily at local midnight.  If the given interval is
+// zero or negative, old versions will only be purged when [Datastore.Purge] is called.
+func WithPurgeInterval(interval time.Duration) Option {
+	return func(o *options) {
+		if interval <= 0 {
+			interval = -1
+		}
+		o.purgeInterval = interval
+	}
+}
+
+// WithRetainVersions ensures that, when purging, the given number of latest versions are
+// retained and may still be read via [Datastore.Snapshot].
+func WithRetainVersions(n uint64) Option {
+	return func(o *options) {
+		o.retainVersions = n
+	}
+}
+
+// WithRetainDuration ensures that, when purging, versions are retained for at least the given
+// duration after they are superseded, and may still be read via [Datastore.Snapshot].
+func WithRetainDuration(d time.Duration) Option {
+	return func(o *options) {
+		o.retainDuration = d
+	}
+}
+
+// WithTxnExpiry sets the duration after which in-flight transactions no longer prevent the
+// versions they read from being purged.
+//
+// Expired transactions may still be used, but may read incomplete data if a purge has
+// taken place.  Defaults to one hour.
+func WithTxnExpiry(expiry time.Duration) Option {
+	return func(o *options) {
+		o.txnExpiry = expiry
+	}
+}
+
+// WithBTreeDegree sets the degree of the btree holding the datastore's values, trading off
+// memory consumption against read and write speed.
+//
+// Defaults to the btree's own default degree.
+func WithBTreeDegree(degree int) Option {
+	return func(o *options) {
+		o.btreeDegree = degree
+	}
+}
+
+// WithClock sets the function used by the datastore to get the current time, used to expire
+// items, transactions and versions.
+//
+// This allows the expiry paths to be tested deterministically.  Defaults to [time.Now].
+func WithClock(now func() time.Time) Option {
+	return func(o *options) {
+		o.clock = now
+	}
+}
+
+// WithCloseOnContextDone sets whether the datastore is closed when the context it was
+// created with is done.
+//
+// If false, the datastore must be explicitly closed.  Defaults to true.
+func WithCloseOnContextDone(close bool) Option {
+	return func(o *options) {
+		o.closeOnContextDone = close
+	}
+}
+
+// WithWatchQueueSize sets the maximum number of commits whose events may be queued for
+// delivery to each watcher created by [Datastore.Watch].
+//
+// Watchers that fall further behind overflow, yielding [corekv.ErrWatchOverflow] before they
+// are closed.  Defaults to 1024.
+func WithWatchQueueSize(n int) Option {
+	return func(o *options) {
+		if n < 1 {
+			n = 1
+		}
+		o.watchQueueSize = n
+	}
+}
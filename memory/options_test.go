@@ -0,0 +1,197 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sourcenetwork/corekv"
+	"github.com/stretchr/testify/require"
+)
+
+// testClock is a manually advanced clock.
+type testClock struct {
+	lk  sync.Mutex
+	now time.Time
+}
+
+func newTestClock() *testClock {
+	return &testClock{
+		now: time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC),
+	}
+}
+
+func (c *testClock) Now() time.Time {
+	c.lk.Lock()
+	defer c.lk.Unlock()
+	return c.now
+}
+
+func (c *testClock) Advance(d time.Duration) {
+	c.lk.Lock()
+	defer c.lk.Unlock()
+	c.now = c.now.Add(d)
+}
+
+func TestWithTxnExpiry(t *testing.T) {
+	ctx := context.Background()
+	clock := newTestClock()
+	s := NewDatastore(ctx, WithClock(clock.Now), WithTxnExpiry(time.Minute))
+
+	err := s.Set(ctx, testKey1, testValue1)
+	require.NoError(t, err)
+
+	txn := s.NewTxn(true)
+	defer txn.Discard(ctx)
+
+	err = s.Set(ctx, testKey1, testValue2)
+	require.NoError(t, err)
+
+	// The open transaction is reading the first version, so it cannot be purged.
+	stats, err := s.Purge(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 0, stats.Removed)
+
+	clock.Advance(2 * time.Minute)
+
+	stats, err = s.Purge(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 1, stats.Removed)
+}
+
+func TestWithClock_TTL(t *testing.T) {
+	ctx := context.Background()
+	clock := newTestClock()
+	s := NewDatastore(ctx, WithClock(clock.Now))
+
+	err := s.SetWithTTL(ctx, testKey1, testValue1, time.Minute)
+	require.NoError(t, err)
+
+	clock.Advance(59 * time.Second)
+
+	resp, err := s.Get(ctx, testKey1)
+	require.NoError(t, err)
+	require.Equal(t, testValue1, resp)
+
+	clock.Advance(time.Second)
+
+	_, err = s.Get(ctx, testKey1)
+	require.ErrorIs(t, err, corekv.ErrNotFound)
+
+	iter := s.Iterator(ctx, corekv.IterOptions{})
+	hasValue, err := iter.Next()
+	require.NoError(t, err)
+	require.False(t, hasValue)
+	err = iter.Close(ctx)
+	require.NoError(t, err)
+}
+
+func TestWithClock_RetainDuration(t *testing.T) {
+	ctx := context.Background()
+	clock := newTestClock()
+	s := NewDatastore(ctx, WithClock(clock.Now), WithRetainDuration(time.Hour))
+
+	err := s.Set(ctx, testKey1, testValue1)
+	require.NoError(t, err)
+	clock.Advance(time.Minute)
+	err = s.Set(ctx, testKey1, testValue2)
+	require.NoError(t, err)
+
+	stats, err := s.Purge(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 0, stats.Removed)
+
+	clock.Advance(time.Hour)
+
+	stats, err = s.Purge(ctx)
+	require.NoError(t, err)
+	require.Equal(t, uint64(2), stats.Version)
+	require.Equal(t, 1, stats.Removed)
+}
+
+func TestWithCloseOnContextDone_False(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	s := NewDatastore(ctx, WithCloseOnContextDone(false))
+	defer s.Close()
+
+	cancel()
+
+	err := s.Set(context.Background(), testKey1, testValue1)
+	require.NoError(t, err)
+
+	resp, err := s.Get(context.Background(), testKey1)
+	require.NoError(t, err)
+	require.Equal(t, testValue1, resp)
+}
+
+func TestContextDone_ClosesDatastore(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	s := NewDatastore(ctx)
+
+	cancel()
+
+	require.Eventually(t, func() bool {
+		_, err := s.Get(context.Background(), testKey1)
+		return err == ErrClosed
+	}, time.Second, time.Millisecond)
+}
+
+func TestWithBTreeDegree(t *testing.T) {
+	ctx := context.Background()
+	s := NewDatastore(ctx, WithBTreeDegree(2))
+
+	for i := 0; i < 100; i++ {
+		err := s.Set(ctx, []byte(fmt.Sprintf("testKey%03d", i)), testValue1)
+		require.NoError(t, err)
+	}
+
+	iter := s.Iterator(ctx, corekv.IterOptions{})
+	for i := 0; i < 100; i++ {
+		hasValue, err := iter.Next()
+		require.NoError(t, err)
+		require.True(t, hasValue)
+		require.Equal(t, []byte(fmt.Sprintf("testKey%03d", i)), iter.Key())
+	}
+	hasValue, err := iter.Next()
+	require.NoError(t, err)
+	require.False(t, hasValue)
+
+	err = iter.Close(ctx)
+	require.NoError(t, err)
+}
+
+func TestWithWatchQueueSize_Overflow(t *testing.T) {
+	ctx := context.Background()
+	s := NewDatastore(ctx, WithWatchQueueSize(2))
+	defer s.Close()
+
+	events, err := s.Watch(ctx, nil)
+	require.NoError(t, err)
+
+	keys := [][]byte{}
+	for i := 0; i < 10; i++ {
+		key := []byte(fmt.Sprintf("k%v", i))
+		keys = append(keys, key)
+		err = s.Set(ctx, key, testValue1)
+		require.NoError(t, err)
+	}
+
+	// At most one commit may be in the process of being delivered, in addition to the
+	// queued commits, before the watcher overflows.  The overflow is reported by a final
+	// event before the watcher is closed.
+	received := []corekv.Event{}
+	for event := range events {
+		received = append(received, event)
+	}
+	require.NotEmpty(t, received)
+	last := received[len(received)-1]
+	require.ErrorIs(t, last.Err, corekv.ErrWatchOverflow)
+
+	received = received[:len(received)-1]
+	require.LessOrEqual(t, len(received), 3)
+	for i, event := range received {
+		require.Equal(t, keys[i], event.Key)
+	}
+}
@@ -0,0 +1,407 @@
+package memory
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/sourcenetwork/corekv"
+)
+
+const (
+	walFileName      = "wal"
+	snapshotFileName = "snapshot"
+	lockFileName     = "lock"
+
+	// compactionThreshold is the number of records the WAL may hold before the datastore is
+	// compacted into a new snapshot file.
+	compactionThreshold = 1000
+)
+
+// snapshotFileHeader is written at the start of every snapshot file, the last byte is the
+// version of the snapshot file format.
+var snapshotFileHeader = []byte("corekv-memory\x01")
+
+// persister durably records the changes committed to a datastore.
+//
+// Each commit is appended to the write-ahead log (WAL) as a single record before it is
+// applied to the datastore.  Once the WAL has grown large enough, the latest state of the
+// datastore is written to a compacted snapshot file in the background, and the WAL is emptied.
+type persister struct {
+	dir string
+	wal walFile
+
+	// The lock file held for as long as the datastore is open, preventing the directory from
+	// being opened by another datastore.
+	lock *os.File
+
+	// The number of records held in the WAL.
+	records int
+
+	// The number of records the WAL may hold before it is next compacted.  This is raised
+	// after a failed compaction so that it is not re-attempted on every following write.
+	compactAt int
+
+	// Signals the background compaction that the WAL has reached compactAt records.
+	compactSignal chan struct{}
+
+	// The size of the complete records held in the WAL, at which the next record will be
+	// written.
+	offset int64
+
+	// Set if the WAL could not be restored to a consistent state after a failed write, all
+	// further writes will be rejected with this error.
+	err error
+}
+
+// walFile is the file holding the WAL.
+//
+// It is satisfied by [*os.File], and allows faults to be injected in tests.
+type walFile interface {
+	io.ReadWriteSeeker
+	io.Closer
+	Truncate(size int64) error
+	Sync() error
+}
+
+// Open returns a new [Datastore], configured with the given options, that persists its changes
+// to the directory at the given path, creating the directory if it does not exist.
+//
+// Any state previously persisted to the directory will be loaded before returning.  Only the
+// latest version of each item is persisted, versions committed before the datastore was
+// opened cannot be read via [Datastore.Snapshot].
+//
+// If the last write to the WAL was interrupted, for example by the process exiting, the
+// incomplete record will be discarded.
+//
+// The directory is locked until the datastore is closed, if it is already locked by another
+// datastore, in this or any other process, [ErrLocked] will be returned.
+func Open(ctx context.Context, path string, opts ...Option) (*Datastore, error) {
+	err := os.MkdirAll(path, 0o755)
+	if err != nil {
+		return nil, err
+	}
+
+	lock, err := lockDir(path)
+	if err != nil {
+		return nil, err
+	}
+
+	d := newDatastore(opts)
+
+	err = d.loadSnapshotFile(path)
+	if err != nil {
+		return nil, errors.Join(err, lock.Close())
+	}
+
+	p, err := d.replayWAL(path)
+	if err != nil {
+		return nil, errors.Join(err, lock.Close())
+	}
+	p.lock = lock
+	d.persister = p
+
+	d.start(ctx)
+	return d, nil
+}
+
+// Compact writes the latest state of the datastore to a new snapshot file, emptying the WAL.
+//
+// This is done automatically, in the background, as the WAL grows, and when the datastore is
+// closed.  Should an automatic compaction fail, it is re-attempted once the WAL has grown by
+// a further 1000 records, Compact may be called to re-attempt it sooner and observe the error.
+// It does nothing if the datastore was not created by [Open].
+func (d *Datastore) Compact(ctx context.Context) error {
+	d.closeLk.RLock()
+	defer d.closeLk.RUnlock()
+	if d.closed {
+		return ErrClosed
+	}
+
+	d.commitLk.Lock()
+	defer d.commitLk.Unlock()
+
+	return d.compact(ctx)
+}
+
+// compact writes the latest state of the datastore to a new snapshot file, emptying the WAL.
+//
+// The commitLk must be held when calling this function.
+func (d *Datastore) compact(ctx context.Context) error {
+	if d.persister == nil {
+		return nil
+	}
+
+	version := d.getVersion()
+	items := []dsItem{}
+	iter := newIter(ctx, d.values, corekv.IterOptions{}, version, d.now)
+	for {
+		hasValue, err := iter.Next()
+		if err != nil {
+			return errors.Join(err, iter.Close(ctx))
+		}
+		if !hasValue {
+			break
+		}
+		items = append(items, iter.item)
+	}
+	err := iter.Close(ctx)
+	if err != nil {
+		return err
+	}
+
+	// The snapshot file is written to a temporary file and then renamed, so that an
+	// interrupted compaction does not leave a partially written snapshot file behind.
+	path := filepath.Join(d.persister.dir, snapshotFileName)
+	tmpPath := path + ".tmp"
+
+	content := appendRecord(append([]byte{}, snapshotFileHeader...), version, items)
+	err = writeFileSync(tmpPath, content)
+	if err != nil {
+		return err
+	}
+	err = os.Rename(tmpPath, path)
+	if err != nil {
+		return err
+	}
+	err = syncDir(d.persister.dir)
+	if err != nil {
+		return err
+	}
+
+	// Should the process exit before the WAL is emptied, the records it holds will be
+	// skipped on replay as they are not newer than the snapshot file.
+	return d.persister.truncate(0)
+}
+
+// compactInBackground compacts the datastore each time the WAL is signalled as having
+// grown large enough, until the datastore is closed.
+//
+// Compaction is done here, rather than by the write that grew the WAL, so that the cost of
+// rewriting the datastore is not borne by the caller committing the write.
+func (d *Datastore) compactInBackground(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-d.closing:
+			return
+		case <-d.persister.compactSignal:
+			err := d.compactIfDue(ctx)
+			if d.opts.onCompacted != nil {
+				d.opts.onCompacted(err)
+			}
+		}
+	}
+}
+
+// compactIfDue compacts the datastore if the WAL holds enough records.
+//
+// If compaction fails it will not be re-attempted until the WAL has grown by a further
+// [compactionThreshold] records.
+func (d *Datastore) compactIfDue(ctx context.Context) error {
+	d.closeLk.RLock()
+	defer d.closeLk.RUnlock()
+	if d.closed {
+		// The datastore is compacted as it is closed.
+		return nil
+	}
+
+	d.commitLk.Lock()
+	defer d.commitLk.Unlock()
+
+	if d.persister.records < d.persister.compactAt {
+		return nil
+	}
+
+	err := d.compact(ctx)
+	if err != nil {
+		d.persister.compactAt = d.persister.records + compactionThreshold
+	}
+	return err
+}
+
+// signalCompaction signals the background compaction if the WAL holds enough records.
+//
+// The commitLk must be held when calling this function.
+func (p *persister) signalCompaction() {
+	if p.records < p.compactAt {
+		return
+	}
+
+	select {
+	case p.compactSignal <- struct{}{}:
+	default:
+		// A compaction has already been signalled.
+	}
+}
+
+// append durably appends the given items, committed at the given version, to the WAL.
+//
+// If the record cannot be fully written, the WAL is truncated back to the end of the
+// previous record so that later records are not appended after an incomplete one.
+func (p *persister) append(version uint64, items []dsItem) error {
+	if p.err != nil {
+		return p.err
+	}
+
+	record := appendRecord(nil, version, items)
+	_, err := p.wal.Write(record)
+	if err == nil {
+		err = p.wal.Sync()
+	}
+	if err != nil {
+		return errors.Join(err, p.truncate(p.offset))
+	}
+
+	p.offset += int64(len(record))
+	p.records++
+	return nil
+}
+
+// truncate truncates the WAL to the given size, positioning the next write at its end.
+//
+// If this fails the WAL may no longer be in a consistent state, and the persister will
+// reject all further writes.
+func (p *persister) truncate(size int64) error {
+	err := p.wal.Truncate(size)
+	if err == nil {
+		_, err = p.wal.Seek(size, io.SeekStart)
+	}
+	if err == nil {
+		err = p.wal.Sync()
+	}
+	if err != nil {
+		p.err = errors.Join(ErrWALFailed, err)
+		return p.err
+	}
+
+	if size == 0 {
+		p.records = 0
+		p.compactAt = compactionThreshold
+	}
+	p.offset = size
+	return nil
+}
+
+// close closes the WAL and releases the lock on the directory.
+func (p *persister) close() error {
+	return errors.Join(p.wal.Close(), p.lock.Close())
+}
+
+// loadSnapshotFile loads the snapshot file held in the given directory into the datastore,
+// if it exists.
+func (d *Datastore) loadSnapshotFile(dir string) error {
+	content, err := os.ReadFile(filepath.Join(dir, snapshotFileName))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if !bytes.HasPrefix(content, snapshotFileHeader) {
+		return ErrCorruptSnapshotFile
+	}
+	content = content[len(snapshotFileHeader):]
+
+	version, items, _, err := readRecord(bufio.NewReader(bytes.NewReader(content)))
+	if err != nil {
+		return ErrCorruptSnapshotFile
+	}
+
+	d.apply(version, items)
+	// Versions prior to the snapshot file's version were not persisted.
+	d.purgedVersion = version
+	return nil
+}
+
+// replayWAL applies the records held in the WAL in the given directory to the datastore,
+// returning a persister that appends to it.
+//
+// Records that are not newer than the datastore are skipped, and any incomplete or corrupt
+// records at the end of the WAL are removed from it.
+func (d *Datastore) replayWAL(dir string) (*persister, error) {
+	wal, err := os.OpenFile(filepath.Join(dir, walFileName), os.O_RDWR|os.O_CREATE, 0o644)
+	if err != nil {
+		return nil, err
+	}
+
+	records := 0
+	var offset int64
+	r := bufio.NewReader(wal)
+	for {
+		version, items, size, err := readRecord(r)
+		if err != nil {
+			if errors.Is(err, io.EOF) || errors.Is(err, errCorruptRecord) {
+				break
+			}
+			return nil, errors.Join(err, wal.Close())
+		}
+
+		offset += int64(size)
+		records++
+		if version > d.getVersion() {
+			d.apply(version, items)
+		}
+	}
+
+	p := &persister{
+		dir:           dir,
+		wal:           wal,
+		records:       records,
+		compactAt:     compactionThreshold,
+		compactSignal: make(chan struct{}, 1),
+	}
+	err = p.truncate(offset)
+	if err != nil {
+		return nil, errors.Join(err, wal.Close())
+	}
+
+	// The WAL may have just been created, the directory is synced so that its entry is
+	// not lost.
+	err = syncDir(dir)
+	if err != nil {
+		return nil, errors.Join(err, wal.Close())
+	}
+
+	return p, nil
+}
+
+// apply sets the given items, committed at the given version, on the datastore, advancing
+// the datastore to that version.
+func (d *Datastore) apply(version uint64, items []dsItem) {
+	for _, item := range items {
+		d.values.Set(item)
+	}
+	*d.version = version
+}
+
+// writeFileSync writes the given content to a new file at the given path, ensuring it has
+// been flushed to disk before returning.
+func writeFileSync(path string, content []byte) error {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o644)
+	if err != nil {
+		return err
+	}
+
+	_, err = f.Write(content)
+	if err == nil {
+		err = f.Sync()
+	}
+	return errors.Join(err, f.Close())
+}
+
+// syncDir flushes the entries of the directory at the given path to disk, ensuring that
+// files created within it, or renamed into it, are not lost.
+func syncDir(path string) error {
+	dir, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	return errors.Join(dir.Sync(), dir.Close())
+}
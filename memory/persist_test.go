@@ -0,0 +1,395 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/sourcenetwork/corekv"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOpen_Reopen(t *testing.T) {
+	ctx := context.Background()
+	path := t.TempDir()
+
+	s, err := Open(ctx, path)
+	require.NoError(t, err)
+
+	err = s.Set(ctx, testKey1, testValue1)
+	require.NoError(t, err)
+	err = s.Set(ctx, testKey2, testValue2)
+	require.NoError(t, err)
+	err = s.Set(ctx, testKey4, nil)
+	require.NoError(t, err)
+	err = s.Delete(ctx, testKey2)
+	require.NoError(t, err)
+	version := s.Version()
+
+	err = s.Close()
+	require.NoError(t, err)
+
+	s, err = Open(ctx, path)
+	require.NoError(t, err)
+	defer s.Close()
+
+	require.Equal(t, version, s.Version())
+
+	resp, err := s.Get(ctx, testKey1)
+	require.NoError(t, err)
+	require.Equal(t, testValue1, resp)
+
+	_, err = s.Get(ctx, testKey2)
+	require.ErrorIs(t, err, corekv.ErrNotFound)
+
+	has, err := s.Has(ctx, testKey4)
+	require.NoError(t, err)
+	require.True(t, has)
+}
+
+func TestOpen_ReplaysWAL(t *testing.T) {
+	ctx := context.Background()
+	path := t.TempDir()
+
+	s, err := Open(ctx, path)
+	require.NoError(t, err)
+
+	err = s.Set(ctx, testKey1, testValue1)
+	require.NoError(t, err)
+	err = s.Compact(ctx)
+	require.NoError(t, err)
+
+	txn := s.NewTxn(false)
+	err = txn.Set(ctx, testKey1, testValue2)
+	require.NoError(t, err)
+	err = txn.Set(ctx, testKey2, testValue2)
+	require.NoError(t, err)
+	err = txn.Commit(ctx)
+	require.NoError(t, err)
+
+	// Simulate the process exiting without closing the datastore, which would compact it.
+	err = s.persister.close()
+	require.NoError(t, err)
+
+	reopened, err := Open(ctx, path)
+	require.NoError(t, err)
+	defer reopened.Close()
+
+	require.Equal(t, s.Version(), reopened.Version())
+
+	resp, err := reopened.Get(ctx, testKey1)
+	require.NoError(t, err)
+	require.Equal(t, testValue2, resp)
+
+	resp, err = reopened.Get(ctx, testKey2)
+	require.NoError(t, err)
+	require.Equal(t, testValue2, resp)
+}
+
+func TestOpen_TruncatedWAL(t *testing.T) {
+	ctx := context.Background()
+	path := t.TempDir()
+
+	s, err := Open(ctx, path)
+	require.NoError(t, err)
+
+	err = s.Set(ctx, testKey1, testValue1)
+	require.NoError(t, err)
+	err = s.Set(ctx, testKey2, testValue2)
+	require.NoError(t, err)
+
+	err = s.persister.close()
+	require.NoError(t, err)
+
+	// Simulate the process exiting part way through writing the last record.
+	walPath := filepath.Join(path, walFileName)
+	info, err := os.Stat(walPath)
+	require.NoError(t, err)
+	err = os.Truncate(walPath, info.Size()-3)
+	require.NoError(t, err)
+
+	s, err = Open(ctx, path)
+	require.NoError(t, err)
+
+	resp, err := s.Get(ctx, testKey1)
+	require.NoError(t, err)
+	require.Equal(t, testValue1, resp)
+
+	_, err = s.Get(ctx, testKey2)
+	require.ErrorIs(t, err, corekv.ErrNotFound)
+
+	// The incomplete record must have been removed, allowing new records to be appended.
+	err = s.Set(ctx, testKey4, testValue4)
+	require.NoError(t, err)
+	err = s.persister.close()
+	require.NoError(t, err)
+
+	s, err = Open(ctx, path)
+	require.NoError(t, err)
+	defer s.Close()
+
+	resp, err = s.Get(ctx, testKey4)
+	require.NoError(t, err)
+	require.Equal(t, testValue4, resp)
+}
+
+// faultyWAL wraps a [walFile], allowing write and truncate failures to be injected.
+type faultyWAL struct {
+	walFile
+
+	// If true, the next write will only write half of the given bytes before failing.
+	tearNextWrite bool
+	// If true, truncations will fail.
+	failTruncate bool
+}
+
+var errInjected = errors.New("injected fault")
+
+func (f *faultyWAL) Write(b []byte) (int, error) {
+	if f.tearNextWrite {
+		f.tearNextWrite = false
+		n, err := f.walFile.Write(b[:len(b)/2])
+		if err != nil {
+			return n, err
+		}
+		return n, errInjected
+	}
+	return f.walFile.Write(b)
+}
+
+func (f *faultyWAL) Truncate(size int64) error {
+	if f.failTruncate {
+		return errInjected
+	}
+	return f.walFile.Truncate(size)
+}
+
+func TestOpen_TornWriteFollowedByWrite(t *testing.T) {
+	ctx := context.Background()
+	path := t.TempDir()
+
+	s, err := Open(ctx, path)
+	require.NoError(t, err)
+
+	err = s.Set(ctx, testKey1, testValue1)
+	require.NoError(t, err)
+
+	wal := &faultyWAL{walFile: s.persister.wal, tearNextWrite: true}
+	s.persister.wal = wal
+
+	err = s.Set(ctx, testKey2, testValue2)
+	require.ErrorIs(t, err, errInjected)
+
+	err = s.Set(ctx, testKey4, testValue4)
+	require.NoError(t, err)
+
+	// Simulate the process exiting without closing the datastore, which would compact it.
+	err = s.persister.close()
+	require.NoError(t, err)
+
+	s, err = Open(ctx, path)
+	require.NoError(t, err)
+	defer s.Close()
+
+	resp, err := s.Get(ctx, testKey1)
+	require.NoError(t, err)
+	require.Equal(t, testValue1, resp)
+
+	_, err = s.Get(ctx, testKey2)
+	require.ErrorIs(t, err, corekv.ErrNotFound)
+
+	// The write acknowledged after the torn write must not have been lost.
+	resp, err = s.Get(ctx, testKey4)
+	require.NoError(t, err)
+	require.Equal(t, testValue4, resp)
+}
+
+func TestOpen_TornWriteNotRestored_RejectsWrites(t *testing.T) {
+	ctx := context.Background()
+	path := t.TempDir()
+
+	s, err := Open(ctx, path)
+	require.NoError(t, err)
+	defer s.Close()
+
+	s.persister.wal = &faultyWAL{walFile: s.persister.wal, tearNextWrite: true, failTruncate: true}
+
+	err = s.Set(ctx, testKey1, testValue1)
+	require.ErrorIs(t, err, errInjected)
+	require.ErrorIs(t, err, ErrWALFailed)
+
+	err = s.Set(ctx, testKey2, testValue2)
+	require.ErrorIs(t, err, ErrWALFailed)
+
+	_, err = s.Get(ctx, testKey2)
+	require.ErrorIs(t, err, corekv.ErrNotFound)
+}
+
+func TestOpen_CompactsWAL(t *testing.T) {
+	ctx := context.Background()
+	path := t.TempDir()
+
+	compacted := make(chan error, 1)
+	s, err := Open(ctx, path, func(o *options) {
+		o.onCompacted = func(err error) {
+			compacted <- err
+		}
+	})
+	require.NoError(t, err)
+
+	for i := 0; i < compactionThreshold; i++ {
+		err = s.Set(ctx, testKey1, testValue1)
+		require.NoError(t, err)
+	}
+	require.NoError(t, <-compacted)
+
+	info, err := os.Stat(filepath.Join(path, walFileName))
+	require.NoError(t, err)
+	require.Equal(t, int64(0), info.Size())
+
+	err = s.Close()
+	require.NoError(t, err)
+
+	s, err = Open(ctx, path)
+	require.NoError(t, err)
+	defer s.Close()
+
+	require.Equal(t, uint64(compactionThreshold), s.Version())
+
+	resp, err := s.Get(ctx, testKey1)
+	require.NoError(t, err)
+	require.Equal(t, testValue1, resp)
+}
+
+func TestOpen_CompactionFails_BacksOff(t *testing.T) {
+	ctx := context.Background()
+	path := t.TempDir()
+
+	compacted := make(chan error, 1)
+	s, err := Open(ctx, path, func(o *options) {
+		o.onCompacted = func(err error) {
+			compacted <- err
+		}
+	})
+	require.NoError(t, err)
+	defer s.Close()
+
+	// A directory in place of the temporary snapshot file prevents the snapshot being written.
+	blocker := filepath.Join(path, snapshotFileName+".tmp")
+	err = os.Mkdir(blocker, 0o755)
+	require.NoError(t, err)
+
+	for i := 0; i < compactionThreshold; i++ {
+		err = s.Set(ctx, testKey1, testValue1)
+		require.NoError(t, err)
+	}
+	require.Error(t, <-compacted)
+
+	// The failed compaction must not be re-attempted by the following writes.
+	err = s.Set(ctx, testKey1, testValue2)
+	require.NoError(t, err)
+
+	s.commitLk.Lock()
+	require.Equal(t, compactionThreshold+1, s.persister.records)
+	require.Equal(t, 2*compactionThreshold, s.persister.compactAt)
+	s.commitLk.Unlock()
+
+	err = os.Remove(blocker)
+	require.NoError(t, err)
+
+	err = s.Compact(ctx)
+	require.NoError(t, err)
+
+	s.commitLk.Lock()
+	require.Equal(t, 0, s.persister.records)
+	require.Equal(t, compactionThreshold, s.persister.compactAt)
+	s.commitLk.Unlock()
+}
+
+func TestOpen_Locked_Errors(t *testing.T) {
+	ctx := context.Background()
+	path := t.TempDir()
+
+	s, err := Open(ctx, path)
+	require.NoError(t, err)
+
+	_, err = Open(ctx, path)
+	require.ErrorIs(t, err, ErrLocked)
+
+	err = s.Close()
+	require.NoError(t, err)
+
+	// The lock is released once the datastore is closed.
+	s, err = Open(ctx, path)
+	require.NoError(t, err)
+
+	err = s.Close()
+	require.NoError(t, err)
+}
+
+func TestOpen_TTL(t *testing.T) {
+	ctx := context.Background()
+	path := t.TempDir()
+
+	s, err := Open(ctx, path)
+	require.NoError(t, err)
+
+	err = s.SetWithTTL(ctx, testKey1, testValue1, time.Hour)
+	require.NoError(t, err)
+	err = s.SetWithTTL(ctx, testKey2, testValue2, time.Millisecond)
+	require.NoError(t, err)
+
+	time.Sleep(5 * time.Millisecond)
+
+	err = s.Close()
+	require.NoError(t, err)
+
+	s, err = Open(ctx, path)
+	require.NoError(t, err)
+	defer s.Close()
+
+	resp, err := s.Get(ctx, testKey1)
+	require.NoError(t, err)
+	require.Equal(t, testValue1, resp)
+
+	_, err = s.Get(ctx, testKey2)
+	require.ErrorIs(t, err, corekv.ErrNotFound)
+}
+
+func TestOpen_OlderVersionsPurged(t *testing.T) {
+	ctx := context.Background()
+	path := t.TempDir()
+
+	s, err := Open(ctx, path)
+	require.NoError(t, err)
+
+	err = s.Set(ctx, testKey1, testValue1)
+	require.NoError(t, err)
+	v1 := s.Version()
+	err = s.Set(ctx, testKey1, testValue2)
+	require.NoError(t, err)
+
+	err = s.Close()
+	require.NoError(t, err)
+
+	s, err = Open(ctx, path)
+	require.NoError(t, err)
+	defer s.Close()
+
+	_, err = s.Snapshot(v1)
+	require.ErrorIs(t, err, ErrVersionPurged)
+}
+
+func TestOpen_CorruptSnapshotFile_Errors(t *testing.T) {
+	ctx := context.Background()
+	path := t.TempDir()
+
+	err := os.WriteFile(filepath.Join(path, snapshotFileName), []byte("not a snapshot"), 0o644)
+	require.NoError(t, err)
+
+	_, err = Open(ctx, path)
+	require.ErrorIs(t, err, ErrCorruptSnapshotFile)
+}
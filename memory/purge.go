@@ -0,0 +1,247 @@
+package memory
+
+import (
+	"bytes"
+	"context"
+	"time"
+)
+
+// purgeBatchSize is the maximum number of item versions collected for removal before they
+// are removed from the datastore, preventing too much from being loaded into memory.
+const purgeBatchSize = 1000
+
+// PurgeStats describes the outcome of a purge.
+type PurgeStats struct {
+	// The version below which item versions were purged.
+	//
+	// Snapshots can no longer be created at versions lower than this.
+	Version uint64
+
+	// The number of item versions removed from the datastore.
+	Removed int
+
+	// How long the purge took.
+	Duration time.Duration
+}
+
+// versionTime records the time at which a version was committed.
+type versionTime struct {
+	version     uint64
+	committedAt time.Time
+}
+
+// Purge removes the item versions that can no longer be read, taking into account the
+// versions read by in-flight transactions and open snapshots, and the configured version
+// retention.
+//
+// If the given context is cancelled part way through, the context's error will be returned
+// along with the statistics of the partial purge.
+func (d *Datastore) Purge(ctx context.Context) (PurgeStats, error) {
+	d.closeLk.RLock()
+	defer d.closeLk.RUnlock()
+	if d.closed {
+		return PurgeStats{}, ErrClosed
+	}
+
+	return d.executePurge(ctx)
+}
+
+// purgeOldVersions will execute the purge on the configured schedule until the datastore
+// is closed.
+func (d *Datastore) purgeOldVersions(ctx context.Context) {
+	if d.opts.purgeInterval < 0 {
+		return
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-d.closing:
+			return
+		case <-time.After(d.untilNextPurge()):
+			stats, _ := d.executePurge(ctx)
+			if d.opts.onPurged != nil {
+				d.opts.onPurged(stats)
+			}
+		}
+	}
+}
+
+// untilNextPurge returns the time until the next scheduled purge.
+//
+// If no purge interval was configured, purges are scheduled for local midnight each day.
+func (d *Datastore) untilNextPurge() time.Duration {
+	if d.opts.purgeInterval > 0 {
+		return d.opts.purgeInterval
+	}
+
+	now := d.now()
+	return time.Date(now.Year(), now.Month(), now.Day()+1, 0, 0, 0, 0, now.Location()).Sub(now)
+}
+
+// executePurge removes item versions that can no longer be read.
+//
+// For each key, all the versions older than the latest version below the purge version are
+// removed.  If that latest version is a deletion, or has expired, it is also removed.
+//
+// Purging stops, leaving the remaining old versions in place, if the given context is
+// cancelled.
+func (d *Datastore) executePurge(ctx context.Context) (PurgeStats, error) {
+	d.purgeLk.Lock()
+	defer d.purgeLk.Unlock()
+
+	started := d.now()
+	stats := PurgeStats{
+		// purging bellow this version
+		Version: d.purgeVersion(),
+	}
+
+	var from *dsItem
+	for {
+		if err := ctx.Err(); err != nil {
+			stats.Duration = d.now().Sub(started)
+			return stats, err
+		}
+
+		itemsToDelete, next := d.collectPurgeable(from, stats.Version)
+		for _, item := range itemsToDelete {
+			d.values.Delete(item)
+		}
+		stats.Removed += len(itemsToDelete)
+
+		if next == nil {
+			break
+		}
+		from = next
+	}
+
+	stats.Duration = d.now().Sub(started)
+	return stats, nil
+}
+
+// collectPurgeable returns up to [purgeBatchSize] item versions that may be purged, starting
+// from the given item (or the first item if nil).
+//
+// If the values have not been exhausted, the item from which the next batch should be
+// collected is also returned.
+func (d *Datastore) collectPurgeable(from *dsItem, version uint64) ([]dsItem, *dsItem) {
+	iter := d.values.Iter()
+	defer iter.Release()
+
+	var hasItem bool
+	if from == nil {
+		hasItem = iter.First()
+	} else {
+		hasItem = iter.Seek(*from)
+	}
+
+	itemsToDelete := []dsItem{}
+	now := d.now()
+
+	// The latest version of the current key, that is not newer than the purge version.
+	var latest *dsItem
+	for ; hasItem; hasItem = iter.Next() {
+		item := iter.Item()
+
+		if latest != nil && !bytes.Equal(latest.key, item.key) {
+			if expireItem(*latest, now).isDeleted {
+				itemsToDelete = append(itemsToDelete, *latest)
+			}
+			latest = nil
+
+			if len(itemsToDelete) >= purgeBatchSize {
+				return itemsToDelete, &item
+			}
+		}
+
+		if item.version > version {
+			continue
+		}
+
+		if latest != nil {
+			itemsToDelete = append(itemsToDelete, *latest)
+		}
+		latest = &item
+	}
+
+	if latest != nil && expireItem(*latest, now).isDeleted {
+		itemsToDelete = append(itemsToDelete, *latest)
+	}
+	return itemsToDelete, nil
+}
+
+// purgeVersion returns the version below which old item versions may be purged, taking
+// into account in-flight transactions, open snapshots and the configured version retention.
+//
+// Once called, new snapshots will not be able to be created at versions lower than the
+// returned value.
+func (d *Datastore) purgeVersion() uint64 {
+	// Expired transactions must not prevent versions from being purged.
+	d.clearOldInFlightTxn()
+
+	d.snapshotLk.Lock()
+	defer d.snapshotLk.Unlock()
+
+	v := d.getVersion()
+	if dsTxn, hasMin := d.inFlightTxn.Min(); hasMin && dsTxn.dsVersion < v {
+		v = dsTxn.dsVersion
+	}
+	for snapshotVersion := range d.snapshots {
+		if snapshotVersion < v {
+			v = snapshotVersion
+		}
+	}
+
+	if d.opts.retainVersions > 0 {
+		// The latest version counts towards the retained versions.
+		var retained uint64
+		if latest := d.getVersion() + 1; latest > d.opts.retainVersions {
+			retained = latest - d.opts.retainVersions
+		}
+		if retained < v {
+			v = retained
+		}
+	}
+
+	if d.opts.retainDuration > 0 {
+		retained := d.versionAt(d.now().Add(-d.opts.retainDuration))
+		if retained < v {
+			v = retained
+		}
+	}
+
+	if v > d.purgedVersion {
+		d.purgedVersion = v
+	}
+	return v
+}
+
+// versionAt returns the latest version committed at or before the given time, forgetting the
+// commit times of the versions before it.
+//
+// Zero will be returned if the commit time of no such version is known.
+func (d *Datastore) versionAt(t time.Time) uint64 {
+	d.commitTimesLk.Lock()
+	defer d.commitTimesLk.Unlock()
+
+	d.trimCommitTimes(t)
+	if len(d.commitTimes) == 0 || d.commitTimes[0].committedAt.After(t) {
+		return 0
+	}
+	return d.commitTimes[0].version
+}
+
+// trimCommitTimes forgets the commit times of the versions before the latest version
+// committed at or before the given time.
+//
+// The commitTimesLk must be held when calling this function.
+func (d *Datastore) trimCommitTimes(t time.Time) {
+	i := 0
+	for i < len(d.commitTimes) && !d.commitTimes[i].committedAt.After(t) {
+		i++
+	}
+	if i > 1 {
+		d.commitTimes = d.commitTimes[i-1:]
+	}
+}
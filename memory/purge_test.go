@@ -0,0 +1,222 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPurge(t *testing.T) {
+	ctx := context.Background()
+	s := NewDatastore(ctx)
+
+	for i := 0; i < 3; i++ {
+		err := s.Set(ctx, testKey1, testValue1)
+		require.NoError(t, err)
+	}
+	err := s.Set(ctx, testKey2, testValue2)
+	require.NoError(t, err)
+
+	stats, err := s.Purge(ctx)
+	require.NoError(t, err)
+
+	require.Equal(t, uint64(4), stats.Version)
+	require.Equal(t, 2, stats.Removed)
+	require.Equal(t, 2, s.values.Len())
+
+	resp, err := s.Get(ctx, testKey1)
+	require.NoError(t, err)
+	require.Equal(t, testValue1, resp)
+}
+
+func TestPurge_RemovesDeletedKeys(t *testing.T) {
+	ctx := context.Background()
+	s := NewDatastore(ctx)
+
+	err := s.Set(ctx, testKey1, testValue1)
+	require.NoError(t, err)
+	err = s.Delete(ctx, testKey1)
+	require.NoError(t, err)
+
+	stats, err := s.Purge(ctx)
+	require.NoError(t, err)
+
+	require.Equal(t, 2, stats.Removed)
+	require.Equal(t, 0, s.values.Len())
+
+	has, err := s.Has(ctx, testKey1)
+	require.NoError(t, err)
+	require.False(t, has)
+}
+
+func TestPurge_ManyKeys(t *testing.T) {
+	ctx := context.Background()
+	s := NewDatastore(ctx)
+
+	for j := 0; j < 2; j++ {
+		batch, err := s.Batch(ctx)
+		require.NoError(t, err)
+		for i := 0; i < 3*purgeBatchSize; i++ {
+			err := batch.Set(ctx, []byte(fmt.Sprintf("testKey%d", i)), testValue1)
+			require.NoError(t, err)
+		}
+		err = batch.Commit(ctx)
+		require.NoError(t, err)
+	}
+
+	stats, err := s.Purge(ctx)
+	require.NoError(t, err)
+
+	require.Equal(t, 3*purgeBatchSize, stats.Removed)
+	require.Equal(t, 3*purgeBatchSize, s.values.Len())
+}
+
+func TestPurge_RetainVersions(t *testing.T) {
+	ctx := context.Background()
+	s := NewDatastore(ctx, WithRetainVersions(2))
+
+	for i := 0; i < 5; i++ {
+		err := s.Set(ctx, testKey1, []byte(fmt.Sprint(i)))
+		require.NoError(t, err)
+	}
+
+	stats, err := s.Purge(ctx)
+	require.NoError(t, err)
+
+	require.Equal(t, uint64(4), stats.Version)
+	require.Equal(t, 3, stats.Removed)
+
+	snapshot, err := s.Snapshot(4)
+	require.NoError(t, err)
+	defer snapshot.Close()
+
+	resp, err := snapshot.Get(ctx, testKey1)
+	require.NoError(t, err)
+	require.Equal(t, []byte("3"), resp)
+
+	_, err = s.Snapshot(3)
+	require.ErrorIs(t, err, ErrVersionPurged)
+}
+
+func TestPurge_RetainDuration(t *testing.T) {
+	ctx := context.Background()
+	s := NewDatastore(ctx, WithRetainDuration(time.Hour))
+
+	for i := 0; i < 3; i++ {
+		err := s.Set(ctx, testKey1, testValue1)
+		require.NoError(t, err)
+	}
+
+	stats, err := s.Purge(ctx)
+	require.NoError(t, err)
+
+	require.Equal(t, uint64(0), stats.Version)
+	require.Equal(t, 0, stats.Removed)
+
+	snapshot, err := s.Snapshot(1)
+	require.NoError(t, err)
+	err = snapshot.Close()
+	require.NoError(t, err)
+}
+
+func TestPurge_RetainDuration_CommitTimesTrimmedOnCommit(t *testing.T) {
+	ctx := context.Background()
+	clock := newTestClock()
+	s := NewDatastore(ctx, WithRetainDuration(time.Minute), WithPurgeInterval(0), WithClock(clock.Now))
+	defer s.Close()
+
+	for i := 0; i < 100; i++ {
+		err := s.Set(ctx, testKey1, testValue1)
+		require.NoError(t, err)
+		clock.Advance(time.Second)
+	}
+
+	s.commitTimesLk.Lock()
+	defer s.commitTimesLk.Unlock()
+	// Only the commits within the retained duration, and the latest commit before it, remain.
+	require.Len(t, s.commitTimes, 61)
+	require.Equal(t, uint64(40), s.commitTimes[0].version)
+}
+
+func TestPurge_RetainDurationElapsed(t *testing.T) {
+	ctx := context.Background()
+	s := NewDatastore(ctx, WithRetainDuration(10*time.Millisecond))
+
+	for i := 0; i < 2; i++ {
+		err := s.Set(ctx, testKey1, testValue1)
+		require.NoError(t, err)
+	}
+
+	time.Sleep(20 * time.Millisecond)
+
+	err := s.Set(ctx, testKey1, testValue1)
+	require.NoError(t, err)
+
+	stats, err := s.Purge(ctx)
+	require.NoError(t, err)
+
+	require.Equal(t, uint64(2), stats.Version)
+	require.Equal(t, 1, stats.Removed)
+}
+
+func TestPurge_Interval(t *testing.T) {
+	ctx := context.Background()
+
+	var lk sync.Mutex
+	var removed int
+	s := NewDatastore(
+		ctx,
+		WithPurgeInterval(5*time.Millisecond),
+		func(o *options) {
+			o.onPurged = func(stats PurgeStats) {
+				lk.Lock()
+				defer lk.Unlock()
+				removed += stats.Removed
+			}
+		},
+	)
+	defer s.Close()
+
+	for i := 0; i < 3; i++ {
+		err := s.Set(ctx, testKey1, testValue1)
+		require.NoError(t, err)
+	}
+
+	require.Eventually(t, func() bool {
+		lk.Lock()
+		defer lk.Unlock()
+		return removed == 2
+	}, time.Second, 5*time.Millisecond)
+}
+
+func TestPurge_CancelledContext(t *testing.T) {
+	ctx := context.Background()
+	s := NewDatastore(ctx)
+
+	for i := 0; i < 2; i++ {
+		err := s.Set(ctx, testKey1, testValue1)
+		require.NoError(t, err)
+	}
+
+	cancelledCtx, cancel := context.WithCancel(ctx)
+	cancel()
+
+	_, err := s.Purge(cancelledCtx)
+	require.ErrorIs(t, err, context.Canceled)
+	require.Equal(t, 2, s.values.Len())
+}
+
+func TestPurge_Closed_Errors(t *testing.T) {
+	ctx := context.Background()
+	s := NewDatastore(ctx)
+
+	err := s.Close()
+	require.NoError(t, err)
+
+	_, err = s.Purge(ctx)
+	require.ErrorIs(t, err, ErrClosed)
+}
@@ -0,0 +1,138 @@
+package memory
+
+import (
+	"context"
+	"sync"
+
+	"github.com/sourcenetwork/corekv"
+)
+
+// Snapshot is a read-only view of a [Datastore] pinned to a past committed version.
+//
+// Whilst a Snapshot is open, the item versions that it reads will not be purged from
+// the datastore.  Snapshots must be closed once they are no longer needed.
+type Snapshot struct {
+	ds      *Datastore
+	version uint64
+
+	closed  bool
+	closeLk sync.RWMutex
+}
+
+var _ corekv.Reader = (*Snapshot)(nil)
+
+// Snapshot returns a new [Snapshot] of the datastore at the given version.
+//
+// Version zero represents the empty datastore prior to any commits, the latest committed
+// version can be obtained via [Datastore.Version].
+//
+// If the given version has not yet been committed, [ErrInvalidVersion] will be returned. If
+// the given version may have been purged, [ErrVersionPurged] will be returned.
+func (d *Datastore) Snapshot(version uint64) (*Snapshot, error) {
+	d.closeLk.RLock()
+	defer d.closeLk.RUnlock()
+	if d.closed {
+		return nil, ErrClosed
+	}
+
+	d.snapshotLk.Lock()
+	defer d.snapshotLk.Unlock()
+
+	if version > d.getVersion() {
+		return nil, ErrInvalidVersion
+	}
+	if version < d.purgedVersion {
+		return nil, ErrVersionPurged
+	}
+
+	d.snapshots[version]++
+
+	return &Snapshot{
+		ds:      d,
+		version: version,
+	}, nil
+}
+
+// Version returns the datastore version that this snapshot reads from.
+func (s *Snapshot) Version() uint64 {
+	return s.version
+}
+
+// Get implements corekv.Reader.
+func (s *Snapshot) Get(ctx context.Context, key []byte) ([]byte, error) {
+	s.closeLk.RLock()
+	defer s.closeLk.RUnlock()
+	if s.closed {
+		return nil, ErrSnapshotClosed
+	}
+	s.ds.closeLk.RLock()
+	defer s.ds.closeLk.RUnlock()
+	if s.ds.closed {
+		return nil, ErrClosed
+	}
+
+	if len(key) == 0 {
+		return nil, corekv.ErrEmptyKey
+	}
+
+	result := s.ds.get(key, s.version)
+	if result.key == nil || result.isDeleted {
+		return nil, corekv.ErrNotFound
+	}
+	return result.val, nil
+}
+
+// Has implements corekv.Reader.
+func (s *Snapshot) Has(ctx context.Context, key []byte) (bool, error) {
+	s.closeLk.RLock()
+	defer s.closeLk.RUnlock()
+	if s.closed {
+		return false, ErrSnapshotClosed
+	}
+	s.ds.closeLk.RLock()
+	defer s.ds.closeLk.RUnlock()
+	if s.ds.closed {
+		return false, ErrClosed
+	}
+
+	if len(key) == 0 {
+		return false, corekv.ErrEmptyKey
+	}
+
+	result := s.ds.get(key, s.version)
+	return result.key != nil && !result.isDeleted, nil
+}
+
+// Iterator implements corekv.Reader.
+//
+// If the snapshot has been closed, the returned iterator will return [ErrSnapshotClosed] on
+// any attempt to move it.
+func (s *Snapshot) Iterator(ctx context.Context, opts corekv.IterOptions) corekv.Iterator {
+	s.closeLk.RLock()
+	defer s.closeLk.RUnlock()
+
+	iter := newIter(ctx, s.ds.values, opts, s.version, s.ds.now)
+	if s.closed {
+		iter.err = ErrSnapshotClosed
+	}
+	return corekv.Paginate(iter, opts)
+}
+
+// Close releases the snapshot, allowing the versions it pinned to be purged.
+func (s *Snapshot) Close() error {
+	s.closeLk.Lock()
+	defer s.closeLk.Unlock()
+	if s.closed {
+		return nil
+	}
+	s.closed = true
+
+	s.ds.snapshotLk.Lock()
+	defer s.ds.snapshotLk.Unlock()
+
+	s.ds.snapshots[s.version]--
+	if s.ds.snapshots[s.version] == 0 {
+		delete(s.ds.snapshots, s.version)
+	}
+	return nil
+}
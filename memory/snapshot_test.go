@@ -0,0 +1,131 @@
+package memory
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sourcenetwork/corekv"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSnapshotReadsAtVersion(t *testing.T) {
+	ctx := context.Background()
+	s := NewDatastore(ctx)
+
+	err := s.Set(ctx, testKey1, testValue1)
+	require.NoError(t, err)
+	v1 := s.Version()
+
+	err = s.Set(ctx, testKey1, testValue2)
+	require.NoError(t, err)
+	err = s.Set(ctx, testKey2, testValue2)
+	require.NoError(t, err)
+	err = s.Delete(ctx, testKey1)
+	require.NoError(t, err)
+
+	snapshot, err := s.Snapshot(v1)
+	require.NoError(t, err)
+
+	resp, err := snapshot.Get(ctx, testKey1)
+	require.NoError(t, err)
+	require.Equal(t, testValue1, resp)
+
+	has, err := snapshot.Has(ctx, testKey2)
+	require.NoError(t, err)
+	require.False(t, has)
+
+	iter := snapshot.Iterator(ctx, corekv.DefaultIterOptions)
+	hasValue, err := iter.Next()
+	require.NoError(t, err)
+	require.True(t, hasValue)
+	require.Equal(t, testKey1, iter.Key())
+
+	hasValue, err = iter.Next()
+	require.NoError(t, err)
+	require.False(t, hasValue)
+
+	err = iter.Close(ctx)
+	require.NoError(t, err)
+
+	err = snapshot.Close()
+	require.NoError(t, err)
+
+	_, err = snapshot.Get(ctx, testKey1)
+	require.ErrorIs(t, err, ErrSnapshotClosed)
+}
+
+func TestSnapshotFutureVersion_Errors(t *testing.T) {
+	ctx := context.Background()
+	s := newLoadedDatastore(ctx)
+
+	_, err := s.Snapshot(s.Version() + 1)
+	require.ErrorIs(t, err, ErrInvalidVersion)
+}
+
+func TestSnapshotPurge_RetainsPinnedVersion(t *testing.T) {
+	ctx := context.Background()
+	s := NewDatastore(ctx)
+
+	err := s.Set(ctx, testKey1, testValue1)
+	require.NoError(t, err)
+
+	snapshot, err := s.Snapshot(s.Version())
+	require.NoError(t, err)
+
+	err = s.Set(ctx, testKey1, testValue2)
+	require.NoError(t, err)
+
+	s.executePurge(ctx)
+
+	resp, err := snapshot.Get(ctx, testKey1)
+	require.NoError(t, err)
+	require.Equal(t, testValue1, resp)
+
+	err = snapshot.Close()
+	require.NoError(t, err)
+
+	s.executePurge(ctx)
+
+	require.Equal(t, 1, s.values.Len())
+}
+
+func TestSnapshotPurgedVersion_Errors(t *testing.T) {
+	ctx := context.Background()
+	s := NewDatastore(ctx)
+
+	err := s.Set(ctx, testKey1, testValue1)
+	require.NoError(t, err)
+	v1 := s.Version()
+
+	err = s.Set(ctx, testKey1, testValue2)
+	require.NoError(t, err)
+
+	s.executePurge(ctx)
+
+	_, err = s.Snapshot(v1)
+	require.ErrorIs(t, err, ErrVersionPurged)
+}
+
+func TestSnapshotClosed_IteratorErrors(t *testing.T) {
+	ctx := context.Background()
+	s := NewDatastore(ctx)
+	defer s.Close()
+
+	err := s.Set(ctx, testKey1, testValue1)
+	require.NoError(t, err)
+
+	snapshot, err := s.Snapshot(s.Version())
+	require.NoError(t, err)
+	err = snapshot.Close()
+	require.NoError(t, err)
+
+	iter := snapshot.Iterator(ctx, corekv.DefaultIterOptions)
+	_, err = iter.Next()
+	require.ErrorIs(t, err, ErrSnapshotClosed)
+
+	_, err = iter.Seek(testKey1)
+	require.ErrorIs(t, err, ErrSnapshotClosed)
+
+	err = iter.Close(ctx)
+	require.NoError(t, err)
+}
@@ -0,0 +1,53 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/sourcenetwork/corekv"
+)
+
+var _ corekv.TTLWriter = (*Datastore)(nil)
+var _ corekv.TTLWriter = (*basicTxn)(nil)
+
+// SetWithTTL implements corekv.TTLWriter.
+func (d *Datastore) SetWithTTL(ctx context.Context, key, value []byte, ttl time.Duration) error {
+	d.closeLk.RLock()
+	defer d.closeLk.RUnlock()
+	if d.closed {
+		return ErrClosed
+	}
+	if len(key) == 0 {
+		return corekv.ErrEmptyKey
+	}
+	tx := d.newTransaction(false)
+
+	err := tx.SetWithTTL(ctx, key, value, ttl)
+	if err != nil {
+		dErr := tx.Discard(ctx)
+		return errors.Join(err, dErr)
+	}
+	return tx.Commit(ctx)
+}
+
+// SetWithTTL implements corekv.TTLWriter.
+func (t *basicTxn) SetWithTTL(ctx context.Context, key, value []byte, ttl time.Duration) error {
+	t.closeLk.RLock()
+	defer t.closeLk.RUnlock()
+	if t.closed {
+		return ErrClosed
+	}
+	if t.discarded {
+		return ErrTxnDiscarded
+	}
+	if t.readOnly {
+		return ErrReadOnlyTxn
+	}
+	if len(key) == 0 {
+		return corekv.ErrEmptyKey
+	}
+	t.ops.Set(dsItem{key: key, version: t.getTxnVersion(), val: value, expiresAt: t.ds.now().Add(ttl)})
+
+	return nil
+}
@@ -13,6 +13,7 @@ package memory
 import (
 	"bytes"
 	"context"
+	"sort"
 	"sync"
 	"sync/atomic"
 
@@ -24,16 +25,35 @@ import (
 type basicTxn struct {
 	ops *btree.BTreeG[dsItem]
 	ds  *Datastore
+	// Uniquely identifies the transaction within the datastore's in-flight transactions.
+	id uint64
 	// Version of the datastore when the transaction was initiated.
 	dsVersion *uint64
 	readOnly  bool
 	discarded bool
 
+	// The keys, and key ranges, read from the datastore by this transaction.
+	//
+	// The transaction will conflict on commit if any of them have been written to since
+	// the transaction was created.  Keys that were only written to by this transaction are
+	// not included, blind writes never conflict.
+	reads      map[string]struct{}
+	readRanges []keyRange
+	readsLk    sync.Mutex
+
 	closed  bool
 	closeLk sync.RWMutex
 }
 
-// var _ ds.Txn = (*basicTxn)(nil)
+// keyRange is a range of keys, from start inclusive to end exclusive.
+//
+// A nil start or end leaves the range unbounded in that direction.
+type keyRange struct {
+	start []byte
+	end   []byte
+}
+
+var _ corekv.Txn = (*basicTxn)(nil)
 
 func (t *basicTxn) getDSVersion() uint64 {
 	return atomic.LoadUint64(t.dsVersion)
@@ -61,7 +81,12 @@ func (t *basicTxn) Delete(ctx context.Context, key []byte) error {
 		return corekv.ErrEmptyKey
 	}
 
-	item := t.get(key)
+	// The key is not recorded as read, as a no-op deletion has no effect and may be
+	// serialized before any conflicting writes.
+	item, ok := t.getPending(key)
+	if !ok {
+		item = t.ds.get(key, t.getDSVersion())
+	}
 	if item.key == nil || item.isDeleted {
 		// if the key doesn't exist of the item is already deleted, this is a no-op.
 		return nil
@@ -71,7 +96,23 @@ func (t *basicTxn) Delete(ctx context.Context, key []byte) error {
 	return nil
 }
 
+// get returns the latest version of the given key readable by this transaction.
+//
+// If the key has not been written to by this transaction it is read from the datastore,
+// and recorded in the transaction's read set.
 func (t *basicTxn) get(key []byte) dsItem {
+	result, ok := t.getPending(key)
+	if ok {
+		return result
+	}
+
+	t.recordRead(key)
+	return t.ds.get(key, t.getDSVersion())
+}
+
+// getPending returns the pending operation on the given key, if this transaction has
+// written to it.
+func (t *basicTxn) getPending(key []byte) (dsItem, bool) {
 	result := dsItem{}
 	t.ops.Descend(dsItem{key: key, version: t.getTxnVersion()}, func(item dsItem) bool {
 		if bytes.Equal(key, item.key) {
@@ -81,11 +122,28 @@ func (t *basicTxn) get(key []byte) dsItem {
 		return false
 	})
 	if result.key == nil {
-		result = t.ds.get(key, t.getDSVersion())
-		result.isGet = true
-		t.ops.Set(result)
+		return dsItem{}, false
+	}
+	return expireItem(result, t.ds.now()), true
+}
+
+// recordRead adds the given key to the transaction's read set.
+func (t *basicTxn) recordRead(key []byte) {
+	t.readsLk.Lock()
+	defer t.readsLk.Unlock()
+
+	if t.reads == nil {
+		t.reads = map[string]struct{}{}
 	}
-	return result
+	t.reads[string(key)] = struct{}{}
+}
+
+// recordReadRange adds the given key range to the transaction's read set.
+func (t *basicTxn) recordReadRange(start, end []byte) {
+	t.readsLk.Lock()
+	defer t.readsLk.Unlock()
+
+	t.readRanges = append(t.readRanges, keyRange{start: start, end: end})
 }
 
 // Get implements ds.Get.
@@ -248,14 +306,28 @@ func (t *basicTxn) Set(ctx context.Context, key []byte, value []byte) error {
 // 	return e
 // }
 
+// Iterator implements corekv.Reader.
+//
+// The returned iterator yields the pending operations of this transaction merged with
+// the state of the datastore at the time the transaction was created.
+func (t *basicTxn) Iterator(ctx context.Context, opts corekv.IterOptions) corekv.Iterator {
+	start, end, err := opts.Bounds()
+	if err == nil {
+		t.recordReadRange(start, end)
+	}
+
+	return corekv.Paginate(newTxnIterator(ctx, t, opts), opts)
+}
+
 // Discard removes all the operations added to the transaction.
-func (t *basicTxn) Discard(ctx context.Context) {
+func (t *basicTxn) Discard(ctx context.Context) error {
 	if t.discarded {
-		return
+		return nil
 	}
 	t.ops.Clear()
 	t.clearInFlightTxn()
 	t.discarded = true
+	return nil
 }
 
 // Commit saves the operations to the underlying datastore.
@@ -271,6 +343,12 @@ func (t *basicTxn) Commit(ctx context.Context) error {
 	}
 	defer t.Discard(ctx)
 
+	// The transaction is discarded if the context has been cancelled, matching the
+	// behaviour of a failed commit.
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
 	if !t.readOnly {
 		return t.ds.commit(t)
 	}
@@ -278,19 +356,41 @@ func (t *basicTxn) Commit(ctx context.Context) error {
 	return nil
 }
 
+// checkForConflicts returns a [*TxnConflictError] if any of the keys, or key ranges, read by
+// this transaction have been written to since the transaction was created.
+//
+// The commitLk must be held when calling this function.
 func (t *basicTxn) checkForConflicts() error {
-	if t.getDSVersion() == t.ds.getVersion() {
+	dsVersion := t.getDSVersion()
+	latestVersion := t.ds.getVersion()
+	if dsVersion == latestVersion {
 		return nil
 	}
-	iter := t.ops.Iter()
-	defer iter.Release()
-	for iter.Next() {
-		expectedItem := t.ds.get(iter.Item().key, t.getDSVersion())
-		latestItem := t.ds.get(iter.Item().key, t.ds.getVersion())
-		if latestItem.version != expectedItem.version {
-			return ErrTxnConflict
+
+	t.readsLk.Lock()
+	defer t.readsLk.Unlock()
+
+	// The keys are sorted so that the reported conflict is deterministic.
+	keys := make([]string, 0, len(t.reads))
+	for key := range t.reads {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		latestItem := t.ds.get([]byte(key), latestVersion)
+		if latestItem.version > dsVersion {
+			return &TxnConflictError{Key: []byte(key)}
 		}
 	}
+
+	for _, r := range t.readRanges {
+		key, found := t.ds.writtenSince(r, dsVersion, latestVersion)
+		if found {
+			return &TxnConflictError{Key: key}
+		}
+	}
+
 	return nil
 }
 
@@ -299,6 +399,7 @@ func (t *basicTxn) clearInFlightTxn() {
 		dsTxn{
 			dsVersion:  t.getDSVersion(),
 			txnVersion: t.getTxnVersion(),
+			id:         t.id,
 		},
 	)
 	t.ds.clearOldInFlightTxn()
@@ -0,0 +1,203 @@
+package memory
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/sourcenetwork/corekv"
+)
+
+// txnIterator iterates through the pending operations of a transaction merged with the
+// state of the underlying datastore at the time the transaction was created.
+type txnIterator struct {
+	// The context the iterator was created with, moving the iterator will fail once
+	// it is cancelled.
+	ctx context.Context
+
+	// Iterates through the committed values of the datastore, at the transaction's
+	// datastore version.
+	snapshot *iterator
+
+	// Iterates through the pending, uncommitted, operations of the transaction,
+	// including deletions.
+	pending *iterator
+
+	snapshotValid bool
+	pendingValid  bool
+
+	// The iterator from which the current item was yielded.
+	//
+	// If both iterators are positioned on the same key, this will be `pending`.
+	current *iterator
+
+	reverse bool
+
+	// reset is a mutatuble property that indicates whether the iterator should be
+	// returned to the beginning on the next [Next] call.
+	reset bool
+}
+
+var _ corekv.Iterator = (*txnIterator)(nil)
+
+func newTxnIterator(ctx context.Context, t *basicTxn, opts corekv.IterOptions) *txnIterator {
+	pending := newIter(
+		ctx,
+		// The iterator holds a read lock on the tree it iterates through, so we iterate
+		// through a (cheap) copy of the ops, else any writes to the transaction whilst the
+		// iterator is open would deadlock.
+		t.ops.Copy(),
+		opts,
+		t.getTxnVersion(),
+		t.ds.now,
+	)
+	pending.tombstones = true
+
+	return &txnIterator{
+		ctx:      ctx,
+		snapshot: newIter(ctx, t.ds.values, opts, t.getDSVersion(), t.ds.now),
+		pending:  pending,
+		reverse:  opts.Reverse,
+		reset:    true,
+	}
+}
+
+func (iter *txnIterator) Reset() {
+	iter.reset = true
+}
+
+func (iter *txnIterator) Next() (bool, error) {
+	if err := iter.ctx.Err(); err != nil {
+		return false, err
+	}
+	if iter.reset {
+		return iter.restart()
+	}
+
+	if iter.current == nil {
+		return false, nil
+	}
+
+	err := iter.advance()
+	if err != nil {
+		return false, err
+	}
+
+	return iter.settle()
+}
+
+// restart returns the iterator back to it's initial location, allowing re-iteration
+// of the underlying data.
+func (iter *txnIterator) restart() (bool, error) {
+	iter.reset = false
+	iter.snapshot.Reset()
+	iter.pending.Reset()
+
+	var err error
+	iter.snapshotValid, err = iter.snapshot.Next()
+	if err != nil {
+		return false, err
+	}
+	iter.pendingValid, err = iter.pending.Next()
+	if err != nil {
+		return false, err
+	}
+
+	return iter.settle()
+}
+
+// advance moves the iterators past the current key.
+func (iter *txnIterator) advance() error {
+	// The current key must be read before moving either iterator, as `current` is
+	// one of them.
+	key := iter.current.Key()
+
+	var err error
+	if iter.snapshotValid && bytes.Equal(iter.snapshot.Key(), key) {
+		iter.snapshotValid, err = iter.snapshot.Next()
+		if err != nil {
+			return err
+		}
+	}
+	if iter.pendingValid && bytes.Equal(iter.pending.Key(), key) {
+		iter.pendingValid, err = iter.pending.Next()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// settle selects the next item to be yielded from the two underlying iterators, skipping
+// over any items deleted within the transaction.
+func (iter *txnIterator) settle() (bool, error) {
+	for {
+		switch {
+		case !iter.snapshotValid && !iter.pendingValid:
+			iter.current = nil
+			return false, nil
+
+		case !iter.snapshotValid:
+			iter.current = iter.pending
+
+		case !iter.pendingValid:
+			iter.current = iter.snapshot
+
+		default:
+			cmp := bytes.Compare(iter.pending.Key(), iter.snapshot.Key())
+			if iter.reverse {
+				cmp = -cmp
+			}
+			if cmp <= 0 {
+				// Pending operations take priority over the committed values
+				iter.current = iter.pending
+			} else {
+				iter.current = iter.snapshot
+			}
+		}
+
+		if iter.current == iter.pending && iter.pending.item.isDeleted {
+			err := iter.advance()
+			if err != nil {
+				return false, err
+			}
+			continue
+		}
+
+		return true, nil
+	}
+}
+
+func (iter *txnIterator) Key() []byte {
+	return iter.current.Key()
+}
+
+func (iter *txnIterator) Value() ([]byte, error) {
+	return iter.current.Value()
+}
+
+func (iter *txnIterator) Seek(key []byte) (bool, error) {
+	if err := iter.ctx.Err(); err != nil {
+		return false, err
+	}
+	iter.reset = false
+
+	var err error
+	iter.snapshotValid, err = iter.snapshot.Seek(key)
+	if err != nil {
+		return false, err
+	}
+	iter.pendingValid, err = iter.pending.Seek(key)
+	if err != nil {
+		return false, err
+	}
+
+	return iter.settle()
+}
+
+func (iter *txnIterator) Close(ctx context.Context) error {
+	err := iter.pending.Close(ctx)
+	if err != nil {
+		return err
+	}
+	return iter.snapshot.Close(ctx)
+}
@@ -0,0 +1,66 @@
+package memory
+
+import (
+	"context"
+
+	"github.com/sourcenetwork/corekv"
+	"github.com/sourcenetwork/corekv/internal/watch"
+)
+
+var _ corekv.Watchable = (*Datastore)(nil)
+
+// Watch implements corekv.Watchable.
+//
+// The events of up to [WithWatchQueueSize] commits are queued for delivery to each watcher,
+// commits are never blocked by slow watchers.  If a watcher's queue is full when a change
+// is committed, the change is dropped and, once the queued events have been delivered, an
+// event holding [corekv.ErrWatchOverflow] is yielded before the channel is closed.
+func (d *Datastore) Watch(ctx context.Context, prefix []byte) (<-chan corekv.Event, error) {
+	d.closeLk.RLock()
+	defer d.closeLk.RUnlock()
+	if d.closed {
+		return nil, ErrClosed
+	}
+
+	w := watch.New(prefix, d.opts.watchQueueSize)
+
+	d.watchLk.Lock()
+	d.watchers[w] = struct{}{}
+	d.watchLk.Unlock()
+
+	go func() {
+		w.Deliver(ctx, d.closing)
+
+		d.watchLk.Lock()
+		delete(d.watchers, w)
+		d.watchLk.Unlock()
+	}()
+
+	return w.Events(), nil
+}
+
+// publish queues events describing the given committed items for all watchers.
+//
+// The commitLk must be held when calling this function, this ensures that events are
+// published in commit order.
+func (d *Datastore) publish(items []dsItem) {
+	d.watchLk.Lock()
+	defer d.watchLk.Unlock()
+
+	if len(d.watchers) == 0 {
+		return
+	}
+
+	events := make([]corekv.Event, len(items))
+	for i, item := range items {
+		if item.isDeleted {
+			events[i] = corekv.Event{Key: item.key, IsDeleted: true}
+		} else {
+			events[i] = corekv.Event{Key: item.key, Value: item.val}
+		}
+	}
+
+	for w := range d.watchers {
+		w.Publish(events)
+	}
+}
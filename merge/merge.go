@@ -0,0 +1,254 @@
+package merge
+
+import (
+	"bytes"
+	"container/heap"
+	"context"
+	"errors"
+
+	"github.com/sourcenetwork/corekv"
+)
+
+// TieBreaker selects which source an item should be yielded from when more than one
+// of the merged iterators yields the same key.
+//
+// It is given the key, and the indexes (in ascending order) of the sources that yielded it,
+// and must return one of those indexes.
+type TieBreaker func(key []byte, sources []int) int
+
+// FirstWins is a [TieBreaker] that yields the item from the first of the merged iterators.
+func FirstWins(key []byte, sources []int) int {
+	return sources[0]
+}
+
+// LastWins is a [TieBreaker] that yields the item from the last of the merged iterators.
+func LastWins(key []byte, sources []int) int {
+	return sources[len(sources)-1]
+}
+
+// iterator k-way merges a set of iterators in key order.
+type iterator struct {
+	sources []*source
+
+	// The sources currently positioned on a valid item, ordered by key then index.
+	heap sourceHeap
+
+	tieBreaker TieBreaker
+
+	// The source from which the current item was yielded.
+	current *source
+
+	// reset is a mutatuble property that indicates whether the iterator should be
+	// returned to the beginning on the next [Next] call.
+	reset bool
+}
+
+var _ corekv.Iterator = (*iterator)(nil)
+
+// source is one of the merged iterators, and the key it is currently positioned on.
+type source struct {
+	it    corekv.Iterator
+	index int
+	key   []byte
+}
+
+// NewIterator returns an iterator that merges the given iterators in key order.
+//
+// All the given iterators must iterate in the direction given by reverse.  If more than one
+// iterator yields the same key, only the item from the source selected by the given tie
+// breaker will be yielded, if tieBreaker is nil [FirstWins] will be used.
+//
+// Closing the returned iterator will close all of the given iterators.
+func NewIterator(reverse bool, tieBreaker TieBreaker, iterators ...corekv.Iterator) corekv.Iterator {
+	if tieBreaker == nil {
+		tieBreaker = FirstWins
+	}
+
+	sources := make([]*source, len(iterators))
+	for i, it := range iterators {
+		sources[i] = &source{
+			it:    it,
+			index: i,
+		}
+	}
+
+	return &iterator{
+		sources: sources,
+		heap: sourceHeap{
+			reverse: reverse,
+			sources: make([]*source, 0, len(sources)),
+		},
+		tieBreaker: tieBreaker,
+		reset:      true,
+	}
+}
+
+// Iterate returns an iterator that merges the items within the given readers that match
+// the given options.
+//
+// If more than one reader contains the same key, only the item from the reader selected by
+// the given tie breaker will be yielded, if tieBreaker is nil [FirstWins] will be used.
+//
+// The `Limit` and `Offset` options are applied to the merged items, not to each reader.
+func Iterate(
+	ctx context.Context,
+	opts corekv.IterOptions,
+	tieBreaker TieBreaker,
+	readers ...corekv.Reader,
+) corekv.Iterator {
+	sourceOpts := opts
+	sourceOpts.Limit = 0
+	sourceOpts.Offset = 0
+
+	iterators := make([]corekv.Iterator, len(readers))
+	for i, reader := range readers {
+		iterators[i] = reader.Iterator(ctx, sourceOpts)
+	}
+
+	return corekv.Paginate(NewIterator(opts.Reverse, tieBreaker, iterators...), opts)
+}
+
+func (iter *iterator) Reset() {
+	iter.reset = true
+}
+
+func (iter *iterator) Next() (bool, error) {
+	if iter.reset {
+		iter.reset = false
+		return iter.fill(func(s *source) (bool, error) {
+			s.it.Reset()
+			return s.it.Next()
+		})
+	}
+
+	if iter.current == nil {
+		return false, nil
+	}
+
+	// Move all the sources positioned on the current key past it.
+	key := iter.current.key
+	for iter.heap.Len() > 0 && bytes.Equal(iter.heap.sources[0].key, key) {
+		s := heap.Pop(&iter.heap).(*source)
+
+		hasNext, err := s.it.Next()
+		if err != nil {
+			return false, err
+		}
+		if hasNext {
+			s.key = s.it.Key()
+			heap.Push(&iter.heap, s)
+		}
+	}
+
+	return iter.settle(), nil
+}
+
+func (iter *iterator) Key() []byte {
+	return iter.current.key
+}
+
+func (iter *iterator) Value() ([]byte, error) {
+	return iter.current.it.Value()
+}
+
+func (iter *iterator) Seek(key []byte) (bool, error) {
+	iter.reset = false
+	return iter.fill(func(s *source) (bool, error) {
+		return s.it.Seek(key)
+	})
+}
+
+func (iter *iterator) Close(ctx context.Context) error {
+	var errs []error
+	for _, s := range iter.sources {
+		errs = append(errs, s.it.Close(ctx))
+	}
+	return errors.Join(errs...)
+}
+
+// fill moves each source using the given function, then rebuilds the heap from the sources
+// positioned on a valid item.
+func (iter *iterator) fill(move func(*source) (bool, error)) (bool, error) {
+	iter.heap.sources = iter.heap.sources[:0]
+	iter.current = nil
+
+	for _, s := range iter.sources {
+		hasValue, err := move(s)
+		if err != nil {
+			return false, err
+		}
+		if hasValue {
+			s.key = s.it.Key()
+			iter.heap.sources = append(iter.heap.sources, s)
+		}
+	}
+	heap.Init(&iter.heap)
+
+	return iter.settle(), nil
+}
+
+// settle selects the source from which the next item will be yielded.
+//
+// The sources positioned on that item's key are left at the top of the heap.
+func (iter *iterator) settle() bool {
+	if iter.heap.Len() == 0 {
+		iter.current = nil
+		return false
+	}
+
+	top := iter.heap.sources[0]
+
+	// The heap is ordered by key then index, so the sources positioned on the smallest
+	// key can be found by popping them in index order.
+	popped := []*source{heap.Pop(&iter.heap).(*source)}
+	for iter.heap.Len() > 0 && bytes.Equal(iter.heap.sources[0].key, top.key) {
+		popped = append(popped, heap.Pop(&iter.heap).(*source))
+	}
+
+	indexes := make([]int, len(popped))
+	for i, s := range popped {
+		indexes[i] = s.index
+		heap.Push(&iter.heap, s)
+	}
+
+	iter.current = iter.sources[iter.tieBreaker(top.key, indexes)]
+	return true
+}
+
+// sourceHeap is a [heap.Interface] over sources, ordered by key, in the direction of
+// iteration, then index.
+type sourceHeap struct {
+	reverse bool
+	sources []*source
+}
+
+var _ heap.Interface = (*sourceHeap)(nil)
+
+func (h *sourceHeap) Len() int {
+	return len(h.sources)
+}
+
+func (h *sourceHeap) Less(i, j int) bool {
+	cmp := bytes.Compare(h.sources[i].key, h.sources[j].key)
+	if h.reverse {
+		cmp = -cmp
+	}
+	if cmp == 0 {
+		return h.sources[i].index < h.sources[j].index
+	}
+	return cmp < 0
+}
+
+func (h *sourceHeap) Swap(i, j int) {
+	h.sources[i], h.sources[j] = h.sources[j], h.sources[i]
+}
+
+func (h *sourceHeap) Push(x any) {
+	h.sources = append(h.sources, x.(*source))
+}
+
+func (h *sourceHeap) Pop() any {
+	last := h.sources[len(h.sources)-1]
+	h.sources = h.sources[:len(h.sources)-1]
+	return last
+}
@@ -0,0 +1,77 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/sourcenetwork/corekv"
+)
+
+var _ corekv.Batchable = (*instrumentedStore)(nil)
+
+// Batch returns a new batch of the underlying store, the operations made against the batch
+// are recorded in the same way as those made against a transaction.
+//
+// If the underlying store does not support batches [corekv.ErrNotSupported] will be returned.
+func (s *instrumentedStore) Batch(ctx context.Context) (corekv.Batch, error) {
+	store, ok := s.store.(corekv.Batchable)
+	if !ok {
+		return nil, corekv.ErrNotSupported
+	}
+
+	start := time.Now()
+	batch, err := store.Batch(ctx)
+	s.recorder.RecordOperation(OpBatch, time.Since(start), Classify(err))
+	if err != nil {
+		return nil, err
+	}
+
+	return &instrumentedBatch{
+		batch:    batch,
+		recorder: s.recorder,
+	}, nil
+}
+
+type instrumentedBatch struct {
+	batch    corekv.Batch
+	recorder Recorder
+}
+
+var _ corekv.Batch = (*instrumentedBatch)(nil)
+
+func (b *instrumentedBatch) Set(ctx context.Context, key []byte, value []byte) error {
+	start := time.Now()
+	err := b.batch.Set(ctx, key, value)
+	b.recorder.RecordOperation(OpSet, time.Since(start), Classify(err))
+
+	b.recorder.RecordKeySize(OpSet, len(key))
+	b.recorder.RecordValueSize(OpSet, len(value))
+
+	return err
+}
+
+func (b *instrumentedBatch) Delete(ctx context.Context, key []byte) error {
+	start := time.Now()
+	err := b.batch.Delete(ctx, key)
+	b.recorder.RecordOperation(OpDelete, time.Since(start), Classify(err))
+
+	b.recorder.RecordKeySize(OpDelete, len(key))
+
+	return err
+}
+
+func (b *instrumentedBatch) Commit(ctx context.Context) error {
+	start := time.Now()
+	err := b.batch.Commit(ctx)
+	b.recorder.RecordOperation(OpCommit, time.Since(start), Classify(err))
+
+	return err
+}
+
+func (b *instrumentedBatch) Discard(ctx context.Context) error {
+	start := time.Now()
+	err := b.batch.Discard(ctx)
+	b.recorder.RecordOperation(OpDiscard, time.Since(start), Classify(err))
+
+	return err
+}
@@ -0,0 +1,72 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/sourcenetwork/corekv"
+)
+
+var _ corekv.ConditionalWriter = (*instrumentedStore)(nil)
+
+// CompareAndSwap sets the value stored against the given key to newValue, if the currently
+// stored value is equal to expectedOld.
+//
+// If the underlying store does not support conditional writes [corekv.ErrNotSupported] will
+// be returned.
+func (s *instrumentedStore) CompareAndSwap(ctx context.Context, key, expectedOld, newValue []byte) (bool, error) {
+	store, ok := s.store.(corekv.ConditionalWriter)
+	if !ok {
+		return false, corekv.ErrNotSupported
+	}
+
+	start := time.Now()
+	swapped, err := store.CompareAndSwap(ctx, key, expectedOld, newValue)
+	s.recorder.RecordOperation(OpCompareAndSwap, time.Since(start), Classify(err))
+
+	s.recorder.RecordKeySize(OpCompareAndSwap, len(key))
+	s.recorder.RecordValueSize(OpCompareAndSwap, len(newValue))
+
+	return swapped, err
+}
+
+// SetIfAbsent sets the value stored against the given key, if no item currently exists at
+// that key.
+//
+// If the underlying store does not support conditional writes [corekv.ErrNotSupported] will
+// be returned.
+func (s *instrumentedStore) SetIfAbsent(ctx context.Context, key, value []byte) (bool, error) {
+	store, ok := s.store.(corekv.ConditionalWriter)
+	if !ok {
+		return false, corekv.ErrNotSupported
+	}
+
+	start := time.Now()
+	set, err := store.SetIfAbsent(ctx, key, value)
+	s.recorder.RecordOperation(OpSetIfAbsent, time.Since(start), Classify(err))
+
+	s.recorder.RecordKeySize(OpSetIfAbsent, len(key))
+	s.recorder.RecordValueSize(OpSetIfAbsent, len(value))
+
+	return set, err
+}
+
+// DeleteIfEquals removes the item at the given key, if the currently stored value is equal
+// to expected.
+//
+// If the underlying store does not support conditional writes [corekv.ErrNotSupported] will
+// be returned.
+func (s *instrumentedStore) DeleteIfEquals(ctx context.Context, key, expected []byte) (bool, error) {
+	store, ok := s.store.(corekv.ConditionalWriter)
+	if !ok {
+		return false, corekv.ErrNotSupported
+	}
+
+	start := time.Now()
+	deleted, err := store.DeleteIfEquals(ctx, key, expected)
+	s.recorder.RecordOperation(OpDeleteIfEquals, time.Since(start), Classify(err))
+
+	s.recorder.RecordKeySize(OpDeleteIfEquals, len(key))
+
+	return deleted, err
+}
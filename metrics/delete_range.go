@@ -0,0 +1,47 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/sourcenetwork/corekv"
+)
+
+var _ corekv.RangeDeleter = (*instrumentedStore)(nil)
+
+// DeleteRange removes all items within the given range from the underlying store.
+//
+// If the underlying store does not support deleting ranges [corekv.ErrNotSupported] will be
+// returned.
+func (s *instrumentedStore) DeleteRange(ctx context.Context, start, end []byte) error {
+	store, ok := s.store.(corekv.RangeDeleter)
+	if !ok {
+		return corekv.ErrNotSupported
+	}
+
+	began := time.Now()
+	err := store.DeleteRange(ctx, start, end)
+	s.recorder.RecordOperation(OpDeleteRange, time.Since(began), Classify(err))
+
+	return err
+}
+
+// DeletePrefix removes all items with a key beginning with the given prefix from the
+// underlying store.
+//
+// If the underlying store does not support deleting ranges [corekv.ErrNotSupported] will be
+// returned.
+func (s *instrumentedStore) DeletePrefix(ctx context.Context, prefix []byte) error {
+	store, ok := s.store.(corekv.RangeDeleter)
+	if !ok {
+		return corekv.ErrNotSupported
+	}
+
+	start := time.Now()
+	err := store.DeletePrefix(ctx, prefix)
+	s.recorder.RecordOperation(OpDeletePrefix, time.Since(start), Classify(err))
+
+	s.recorder.RecordKeySize(OpDeletePrefix, len(prefix))
+
+	return err
+}
@@ -0,0 +1,62 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/sourcenetwork/corekv"
+)
+
+var (
+	_ corekv.MultiReader = (*instrumentedStore)(nil)
+	_ corekv.MultiReader = (*instrumentedTxn)(nil)
+)
+
+// GetMany returns the values at the given keys, recording the operation as a whole and the
+// size of each key and found value.
+//
+// If the underlying store does not support reading many items [corekv.ErrNotSupported] will
+// be returned.
+func (s *instrumentedRW) GetMany(ctx context.Context, keys [][]byte) ([]corekv.GetResult, error) {
+	rw, ok := s.rw.(corekv.MultiReader)
+	if !ok {
+		return nil, corekv.ErrNotSupported
+	}
+
+	start := time.Now()
+	results, err := rw.GetMany(ctx, keys)
+	s.recorder.RecordOperation(OpGetMany, time.Since(start), Classify(err))
+
+	for _, key := range keys {
+		s.recorder.RecordKeySize(OpGetMany, len(key))
+	}
+	for _, result := range results {
+		if result.Found {
+			s.recorder.RecordValueSize(OpGetMany, len(result.Value))
+		}
+	}
+
+	return results, err
+}
+
+// HasMany returns true for each of the given keys at which an item is found, recording the
+// operation as a whole and the size of each key.
+//
+// If the underlying store does not support reading many items [corekv.ErrNotSupported] will
+// be returned.
+func (s *instrumentedRW) HasMany(ctx context.Context, keys [][]byte) ([]bool, error) {
+	rw, ok := s.rw.(corekv.MultiReader)
+	if !ok {
+		return nil, corekv.ErrNotSupported
+	}
+
+	start := time.Now()
+	results, err := rw.HasMany(ctx, keys)
+	s.recorder.RecordOperation(OpHasMany, time.Since(start), Classify(err))
+
+	for _, key := range keys {
+		s.recorder.RecordKeySize(OpHasMany, len(key))
+	}
+
+	return results, err
+}
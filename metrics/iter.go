@@ -0,0 +1,103 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/sourcenetwork/corekv"
+)
+
+// iterator records the lifetime of, and the number of items yielded by, an iterator.
+type iterator struct {
+	it       corekv.Iterator
+	recorder Recorder
+
+	created time.Time
+	items   int
+
+	// The first error returned by the iterator.
+	err error
+}
+
+var _ corekv.BidirectionalIterator = (*iterator)(nil)
+
+func (iter *iterator) Reset() {
+	iter.it.Reset()
+}
+
+func (iter *iterator) Next() (bool, error) {
+	hasNext, err := iter.it.Next()
+	iter.observe(hasNext, err)
+	return hasNext, err
+}
+
+func (iter *iterator) Key() []byte {
+	return iter.it.Key()
+}
+
+func (iter *iterator) Value() ([]byte, error) {
+	value, err := iter.it.Value()
+	if err != nil {
+		iter.observe(false, err)
+		return nil, err
+	}
+
+	iter.recorder.RecordValueSize(OpIterator, len(value))
+	return value, nil
+}
+
+func (iter *iterator) Seek(key []byte) (bool, error) {
+	hasNext, err := iter.it.Seek(key)
+	iter.observe(hasNext, err)
+	return hasNext, err
+}
+
+// Prev moves the iterator backwards.
+//
+// If the underlying iterator is not bidirectional [corekv.ErrNotSupported] will be returned.
+func (iter *iterator) Prev() (bool, error) {
+	it, ok := iter.it.(corekv.BidirectionalIterator)
+	if !ok {
+		return false, corekv.ErrNotSupported
+	}
+
+	hasPrev, err := it.Prev()
+	iter.observe(false, err)
+	return hasPrev, err
+}
+
+// Peek returns the key of the item that would be yielded by the next `Next` call.
+//
+// If the underlying iterator is not bidirectional [corekv.ErrNotSupported] will be returned.
+func (iter *iterator) Peek() ([]byte, bool, error) {
+	it, ok := iter.it.(corekv.BidirectionalIterator)
+	if !ok {
+		return nil, false, corekv.ErrNotSupported
+	}
+
+	key, hasNext, err := it.Peek()
+	iter.observe(false, err)
+	return key, hasNext, err
+}
+
+// Close closes the iterator, recording its lifetime.
+func (iter *iterator) Close(ctx context.Context) error {
+	err := iter.it.Close(ctx)
+	iter.observe(false, err)
+
+	lifetime := time.Since(iter.created)
+	iter.recorder.RecordIterator(lifetime, iter.items)
+	iter.recorder.RecordOperation(OpIterator, lifetime, Classify(iter.err))
+
+	return err
+}
+
+// observe records the result of moving the iterator.
+func (iter *iterator) observe(hasNext bool, err error) {
+	if hasNext {
+		iter.items++
+	}
+	if err != nil && iter.err == nil {
+		iter.err = err
+	}
+}
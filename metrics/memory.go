@@ -0,0 +1,182 @@
+package metrics
+
+import (
+	"sync"
+	"time"
+)
+
+var (
+	// DurationBuckets are the upper bounds, in seconds, of the buckets used by
+	// [MemoryRecorder] for operation durations and iterator lifetimes.
+	DurationBuckets = []float64{0.00001, 0.0001, 0.001, 0.01, 0.1, 1, 10}
+
+	// SizeBuckets are the upper bounds, in bytes, of the buckets used by [MemoryRecorder]
+	// for key and value sizes.
+	SizeBuckets = []float64{16, 64, 256, 1024, 4096, 16384, 65536, 262144, 1048576}
+
+	// CountBuckets are the upper bounds of the buckets used by [MemoryRecorder] for the
+	// number of items yielded by iterators.
+	CountBuckets = []float64{0, 1, 10, 100, 1000, 10000, 100000}
+)
+
+// Histogram counts observations within a set of cumulative buckets.
+type Histogram struct {
+	// The upper bounds of the buckets, in ascending order.
+	Buckets []float64
+
+	// The number of observations smaller than or equal to each bucket's upper bound.
+	Counts []uint64
+
+	// The sum of all the observations.
+	Sum float64
+
+	// The total number of observations.
+	Count uint64
+}
+
+func newHistogram(buckets []float64) *Histogram {
+	return &Histogram{
+		Buckets: buckets,
+		Counts:  make([]uint64, len(buckets)),
+	}
+}
+
+func (h *Histogram) observe(value float64) {
+	for i, bound := range h.Buckets {
+		if value <= bound {
+			h.Counts[i]++
+		}
+	}
+	h.Sum += value
+	h.Count++
+}
+
+func (h *Histogram) copy() Histogram {
+	result := *h
+	result.Counts = make([]uint64, len(h.Counts))
+	copy(result.Counts, h.Counts)
+	return result
+}
+
+// operationKey identifies the count of an operation completed with a given error class.
+type operationKey struct {
+	op    Operation
+	class ErrorClass
+}
+
+// MemoryRecorder is a [Recorder] that holds measurements in memory.
+type MemoryRecorder struct {
+	lk sync.Mutex
+
+	operations map[operationKey]uint64
+	durations  map[Operation]*Histogram
+	keySizes   map[Operation]*Histogram
+	valueSizes map[Operation]*Histogram
+
+	iteratorLifetimes *Histogram
+	iteratorItems     *Histogram
+}
+
+var _ Recorder = (*MemoryRecorder)(nil)
+
+// NewMemoryRecorder returns a new, empty, [*MemoryRecorder].
+func NewMemoryRecorder() *MemoryRecorder {
+	return &MemoryRecorder{
+		operations:        map[operationKey]uint64{},
+		durations:         map[Operation]*Histogram{},
+		keySizes:          map[Operation]*Histogram{},
+		valueSizes:        map[Operation]*Histogram{},
+		iteratorLifetimes: newHistogram(DurationBuckets),
+		iteratorItems:     newHistogram(CountBuckets),
+	}
+}
+
+func (r *MemoryRecorder) RecordOperation(op Operation, duration time.Duration, class ErrorClass) {
+	r.lk.Lock()
+	defer r.lk.Unlock()
+
+	r.operations[operationKey{op: op, class: class}]++
+	histogramFor(r.durations, op, DurationBuckets).observe(duration.Seconds())
+}
+
+func (r *MemoryRecorder) RecordKeySize(op Operation, size int) {
+	r.lk.Lock()
+	defer r.lk.Unlock()
+
+	histogramFor(r.keySizes, op, SizeBuckets).observe(float64(size))
+}
+
+func (r *MemoryRecorder) RecordValueSize(op Operation, size int) {
+	r.lk.Lock()
+	defer r.lk.Unlock()
+
+	histogramFor(r.valueSizes, op, SizeBuckets).observe(float64(size))
+}
+
+func (r *MemoryRecorder) RecordIterator(lifetime time.Duration, items int) {
+	r.lk.Lock()
+	defer r.lk.Unlock()
+
+	r.iteratorLifetimes.observe(lifetime.Seconds())
+	r.iteratorItems.observe(float64(items))
+}
+
+// OperationCount returns the number of times the given operation has completed with the
+// given error class.
+func (r *MemoryRecorder) OperationCount(op Operation, class ErrorClass) uint64 {
+	r.lk.Lock()
+	defer r.lk.Unlock()
+
+	return r.operations[operationKey{op: op, class: class}]
+}
+
+// Durations returns the histogram of the durations of the given operation.
+func (r *MemoryRecorder) Durations(op Operation) Histogram {
+	r.lk.Lock()
+	defer r.lk.Unlock()
+
+	return histogramFor(r.durations, op, DurationBuckets).copy()
+}
+
+// KeySizes returns the histogram of the key sizes given to the given operation.
+func (r *MemoryRecorder) KeySizes(op Operation) Histogram {
+	r.lk.Lock()
+	defer r.lk.Unlock()
+
+	return histogramFor(r.keySizes, op, SizeBuckets).copy()
+}
+
+// ValueSizes returns the histogram of the value sizes read or written by the given operation.
+func (r *MemoryRecorder) ValueSizes(op Operation) Histogram {
+	r.lk.Lock()
+	defer r.lk.Unlock()
+
+	return histogramFor(r.valueSizes, op, SizeBuckets).copy()
+}
+
+// IteratorLifetimes returns the histogram of the lifetimes, in seconds, of closed iterators.
+func (r *MemoryRecorder) IteratorLifetimes() Histogram {
+	r.lk.Lock()
+	defer r.lk.Unlock()
+
+	return r.iteratorLifetimes.copy()
+}
+
+// IteratorItems returns the histogram of the number of items yielded by closed iterators.
+func (r *MemoryRecorder) IteratorItems() Histogram {
+	r.lk.Lock()
+	defer r.lk.Unlock()
+
+	return r.iteratorItems.copy()
+}
+
+// histogramFor returns the histogram held in the given map against the given operation,
+// creating it if it does not yet exist.
+func histogramFor(histograms map[Operation]*Histogram, op Operation, buckets []float64) *Histogram {
+	h, ok := histograms[op]
+	if !ok {
+		h = newHistogram(buckets)
+		histograms[op] = h
+	}
+	return h
+}
@@ -0,0 +1,88 @@
+package metrics
+
+import (
+	"errors"
+	"time"
+
+	"github.com/sourcenetwork/corekv"
+)
+
+// Operation identifies the kind of store operation being recorded.
+type Operation string
+
+const (
+	OpGet      Operation = "get"
+	OpHas      Operation = "has"
+	OpSet      Operation = "set"
+	OpDelete   Operation = "delete"
+	OpIterator Operation = "iterator"
+	OpCommit   Operation = "commit"
+	OpDiscard  Operation = "discard"
+	OpClose    Operation = "close"
+
+	OpGetMany        Operation = "get_many"
+	OpHasMany        Operation = "has_many"
+	OpSetWithTTL     Operation = "set_with_ttl"
+	OpDeleteRange    Operation = "delete_range"
+	OpDeletePrefix   Operation = "delete_prefix"
+	OpCompareAndSwap Operation = "compare_and_swap"
+	OpSetIfAbsent    Operation = "set_if_absent"
+	OpDeleteIfEquals Operation = "delete_if_equals"
+	OpBatch          Operation = "batch"
+	OpWatch          Operation = "watch"
+)
+
+// ErrorClass groups the errors returned by store operations.
+type ErrorClass string
+
+const (
+	// ErrorClassNone is recorded for operations that did not return an error.
+	ErrorClassNone        ErrorClass = "none"
+	ErrorClassNotFound    ErrorClass = "not_found"
+	ErrorClassTxnConflict ErrorClass = "txn_conflict"
+	ErrorClassDBClosed    ErrorClass = "db_closed"
+	ErrorClassOther       ErrorClass = "other"
+)
+
+// Classify returns the class of the given error.
+func Classify(err error) ErrorClass {
+	switch {
+	case err == nil:
+		return ErrorClassNone
+	case errors.Is(err, corekv.ErrNotFound):
+		return ErrorClassNotFound
+	case errors.Is(err, corekv.ErrTxnConflict):
+		return ErrorClassTxnConflict
+	case errors.Is(err, corekv.ErrDBClosed):
+		return ErrorClassDBClosed
+	default:
+		return ErrorClassOther
+	}
+}
+
+// Recorder receives the measurements taken by an instrumented store.
+//
+// Implementations must be safe for concurrent use.
+type Recorder interface {
+	// RecordOperation records the completion of a single operation, how long it took, and
+	// the class of any error it returned.
+	RecordOperation(op Operation, duration time.Duration, class ErrorClass)
+
+	// RecordKeySize records the size, in bytes, of a key given to an operation.
+	RecordKeySize(op Operation, size int)
+
+	// RecordValueSize records the size, in bytes, of a value read or written by an operation.
+	RecordValueSize(op Operation, size int)
+
+	// RecordIterator records the lifetime of an iterator, from creation to close, and the
+	// number of items it yielded.
+	RecordIterator(lifetime time.Duration, items int)
+}
+
+// Instrumented is a [corekv.Store] that records measurements of its use.
+type Instrumented interface {
+	corekv.Store
+
+	// Recorder returns the recorder that measurements are given to.
+	Recorder() Recorder
+}
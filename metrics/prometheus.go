@@ -0,0 +1,115 @@
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+)
+
+// WritePrometheus writes the measurements held by this recorder to the given writer in the
+// Prometheus text exposition format.
+func (r *MemoryRecorder) WritePrometheus(w io.Writer) error {
+	r.lk.Lock()
+	defer r.lk.Unlock()
+
+	p := &promWriter{w: w}
+
+	p.header("corekv_operations_total", "counter", "Number of completed store operations.")
+	keys := make([]operationKey, 0, len(r.operations))
+	for key := range r.operations {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].op != keys[j].op {
+			return keys[i].op < keys[j].op
+		}
+		return keys[i].class < keys[j].class
+	})
+	for _, key := range keys {
+		p.printf(
+			"corekv_operations_total{op=%q,error=%q} %d\n",
+			key.op, key.class, r.operations[key],
+		)
+	}
+
+	p.opHistograms(
+		"corekv_operation_duration_seconds",
+		"Duration of completed store operations.",
+		r.durations,
+	)
+	p.opHistograms(
+		"corekv_key_size_bytes",
+		"Size of the keys given to store operations.",
+		r.keySizes,
+	)
+	p.opHistograms(
+		"corekv_value_size_bytes",
+		"Size of the values read or written by store operations.",
+		r.valueSizes,
+	)
+
+	p.header("corekv_iterator_lifetime_seconds", "histogram", "Lifetime of closed iterators.")
+	p.histogram("corekv_iterator_lifetime_seconds", "", r.iteratorLifetimes)
+
+	p.header("corekv_iterator_items", "histogram", "Number of items yielded by closed iterators.")
+	p.histogram("corekv_iterator_items", "", r.iteratorItems)
+
+	return p.err
+}
+
+// promWriter writes Prometheus text, holding on to the first error encountered.
+type promWriter struct {
+	w   io.Writer
+	err error
+}
+
+func (p *promWriter) printf(format string, args ...any) {
+	if p.err != nil {
+		return
+	}
+	_, p.err = fmt.Fprintf(p.w, format, args...)
+}
+
+func (p *promWriter) header(name, kind, help string) {
+	p.printf("# HELP %s %s\n", name, help)
+	p.printf("# TYPE %s %s\n", name, kind)
+}
+
+// opHistograms writes the given histograms, labeled by operation.
+func (p *promWriter) opHistograms(name, help string, histograms map[Operation]*Histogram) {
+	p.header(name, "histogram", help)
+
+	ops := make([]Operation, 0, len(histograms))
+	for op := range histograms {
+		ops = append(ops, op)
+	}
+	sort.Slice(ops, func(i, j int) bool { return ops[i] < ops[j] })
+
+	for _, op := range ops {
+		p.histogram(name, fmt.Sprintf("op=%q", op), histograms[op])
+	}
+}
+
+// histogram writes the given histogram, with the given (comma separated) labels.
+func (p *promWriter) histogram(name, labels string, h *Histogram) {
+	sep := ""
+	if labels != "" {
+		sep = ","
+	}
+
+	for i, bound := range h.Buckets {
+		p.printf("%s_bucket{%s%sle=%q} %d\n", name, labels, sep, formatFloat(bound), h.Counts[i])
+	}
+	p.printf("%s_bucket{%s%sle=\"+Inf\"} %d\n", name, labels, sep, h.Count)
+
+	if labels != "" {
+		labels = "{" + labels + "}"
+	}
+	p.printf("%s_sum%s %s\n", name, labels, formatFloat(h.Sum))
+	p.printf("%s_count%s %d\n", name, labels, h.Count)
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
@@ -0,0 +1,150 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/sourcenetwork/corekv"
+)
+
+// readWriter is the set of functions shared by stores and transactions.
+type readWriter interface {
+	corekv.Reader
+	corekv.Writer
+}
+
+// instrumentedRW records measurements of the reads and writes made against a store or
+// transaction.
+type instrumentedRW struct {
+	rw       readWriter
+	recorder Recorder
+}
+
+type instrumentedStore struct {
+	instrumentedRW
+	store corekv.Store
+}
+
+var _ Instrumented = (*instrumentedStore)(nil)
+
+// Wrap returns a store that records measurements of the operations made against the given
+// store to the given recorder.
+//
+// If the given store is a [corekv.TxnStore] the returned store will also be a
+// [corekv.TxnStore], with operations made against its transactions also recorded.
+func Wrap(store corekv.Store, recorder Recorder) Instrumented {
+	istore := &instrumentedStore{
+		instrumentedRW: instrumentedRW{
+			rw:       store,
+			recorder: recorder,
+		},
+		store: store,
+	}
+
+	if _, ok := store.(corekv.TxnStore); ok {
+		return &instrumentedTxnStore{istore}
+	}
+
+	return istore
+}
+
+func (s *instrumentedRW) Get(ctx context.Context, key []byte) ([]byte, error) {
+	start := time.Now()
+	value, err := s.rw.Get(ctx, key)
+	s.recorder.RecordOperation(OpGet, time.Since(start), Classify(err))
+
+	s.recorder.RecordKeySize(OpGet, len(key))
+	if err == nil {
+		s.recorder.RecordValueSize(OpGet, len(value))
+	}
+
+	return value, err
+}
+
+func (s *instrumentedRW) Has(ctx context.Context, key []byte) (bool, error) {
+	start := time.Now()
+	has, err := s.rw.Has(ctx, key)
+	s.recorder.RecordOperation(OpHas, time.Since(start), Classify(err))
+
+	s.recorder.RecordKeySize(OpHas, len(key))
+
+	return has, err
+}
+
+func (s *instrumentedRW) Set(ctx context.Context, key []byte, value []byte) error {
+	start := time.Now()
+	err := s.rw.Set(ctx, key, value)
+	s.recorder.RecordOperation(OpSet, time.Since(start), Classify(err))
+
+	s.recorder.RecordKeySize(OpSet, len(key))
+	s.recorder.RecordValueSize(OpSet, len(value))
+
+	return err
+}
+
+func (s *instrumentedRW) Delete(ctx context.Context, key []byte) error {
+	start := time.Now()
+	err := s.rw.Delete(ctx, key)
+	s.recorder.RecordOperation(OpDelete, time.Since(start), Classify(err))
+
+	s.recorder.RecordKeySize(OpDelete, len(key))
+
+	return err
+}
+
+func (s *instrumentedRW) Iterator(ctx context.Context, opts corekv.IterOptions) corekv.Iterator {
+	return &iterator{
+		it:       s.rw.Iterator(ctx, opts),
+		recorder: s.recorder,
+		created:  time.Now(),
+	}
+}
+
+func (s *instrumentedRW) Recorder() Recorder {
+	return s.recorder
+}
+
+func (s *instrumentedStore) Close() error {
+	start := time.Now()
+	err := s.store.Close()
+	s.recorder.RecordOperation(OpClose, time.Since(start), Classify(err))
+
+	return err
+}
+
+type instrumentedTxnStore struct {
+	*instrumentedStore
+}
+
+var _ corekv.TxnStore = (*instrumentedTxnStore)(nil)
+
+func (s *instrumentedTxnStore) NewTxn(readonly bool) corekv.Txn {
+	return &instrumentedTxn{
+		instrumentedRW: instrumentedRW{
+			rw:       s.store.(corekv.TxnStore).NewTxn(readonly),
+			recorder: s.recorder,
+		},
+	}
+}
+
+type instrumentedTxn struct {
+	instrumentedRW
+}
+
+var _ corekv.Txn = (*instrumentedTxn)(nil)
+
+func (t *instrumentedTxn) Commit(ctx context.Context) error {
+	start := time.Now()
+	err := t.rw.(corekv.Txn).Commit(ctx)
+	t.recorder.RecordOperation(OpCommit, time.Since(start), Classify(err))
+
+	return err
+}
+
+func (t *instrumentedTxn) Discard(ctx context.Context) error {
+	start := time.Now()
+	err := t.rw.(corekv.Txn).Discard(ctx)
+	t.recorder.RecordOperation(OpDiscard, time.Since(start), Classify(err))
+
+	return err
+}
@@ -0,0 +1,34 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/sourcenetwork/corekv"
+)
+
+var (
+	_ corekv.TTLWriter = (*instrumentedStore)(nil)
+	_ corekv.TTLWriter = (*instrumentedTxn)(nil)
+)
+
+// SetWithTTL sets the value stored against the given key, expiring it once the given
+// time-to-live has passed.
+//
+// If the underlying store does not support expiring items [corekv.ErrNotSupported] will be
+// returned.
+func (s *instrumentedRW) SetWithTTL(ctx context.Context, key, value []byte, ttl time.Duration) error {
+	rw, ok := s.rw.(corekv.TTLWriter)
+	if !ok {
+		return corekv.ErrNotSupported
+	}
+
+	start := time.Now()
+	err := rw.SetWithTTL(ctx, key, value, ttl)
+	s.recorder.RecordOperation(OpSetWithTTL, time.Since(start), Classify(err))
+
+	s.recorder.RecordKeySize(OpSetWithTTL, len(key))
+	s.recorder.RecordValueSize(OpSetWithTTL, len(value))
+
+	return err
+}
@@ -0,0 +1,27 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/sourcenetwork/corekv"
+)
+
+var _ corekv.Watchable = (*instrumentedStore)(nil)
+
+// Watch returns a channel yielding the changes committed to items with the given prefix in
+// the underlying store, recording the time taken to begin watching.
+//
+// If the underlying store does not support watching [corekv.ErrNotSupported] will be returned.
+func (s *instrumentedStore) Watch(ctx context.Context, prefix []byte) (<-chan corekv.Event, error) {
+	store, ok := s.store.(corekv.Watchable)
+	if !ok {
+		return nil, corekv.ErrNotSupported
+	}
+
+	start := time.Now()
+	events, err := store.Watch(ctx, prefix)
+	s.recorder.RecordOperation(OpWatch, time.Since(start), Classify(err))
+
+	return events, err
+}
@@ -0,0 +1,63 @@
+package namespace
+
+import (
+	"context"
+
+	"github.com/sourcenetwork/corekv"
+)
+
+var _ corekv.Batchable = (*namespaceStore)(nil)
+
+// Batch returns a new namespaced batch.
+//
+// If the underlying store does not support batches [corekv.ErrNotSupported] will
+// be returned.
+func (nstore *namespaceStore) Batch(ctx context.Context) (corekv.Batch, error) {
+	store, ok := nstore.store.(corekv.Batchable)
+	if !ok {
+		return nil, corekv.ErrNotSupported
+	}
+
+	batch, err := store.Batch(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &namespaceBatch{
+		namespace: nstore.namespace,
+		batch:     batch,
+	}, nil
+}
+
+// namespaceBatch wraps a batch of the underlying store, prefixing all keys
+// written via it with the namespace.
+type namespaceBatch struct {
+	namespace []byte
+	batch     corekv.Batch
+}
+
+var _ corekv.Batch = (*namespaceBatch)(nil)
+
+func (nbatch *namespaceBatch) Set(ctx context.Context, key []byte, value []byte) error {
+	if len(key) == 0 {
+		return corekv.ErrEmptyKey
+	}
+
+	return nbatch.batch.Set(ctx, prefixed(nbatch.namespace, key), value)
+}
+
+func (nbatch *namespaceBatch) Delete(ctx context.Context, key []byte) error {
+	if len(key) == 0 {
+		return corekv.ErrEmptyKey
+	}
+
+	return nbatch.batch.Delete(ctx, prefixed(nbatch.namespace, key))
+}
+
+func (nbatch *namespaceBatch) Commit(ctx context.Context) error {
+	return nbatch.batch.Commit(ctx)
+}
+
+func (nbatch *namespaceBatch) Discard(ctx context.Context) error {
+	return nbatch.batch.Discard(ctx)
+}
@@ -0,0 +1,38 @@
+package namespace
+
+import (
+	"github.com/sourcenetwork/corekv"
+)
+
+var _ corekv.BidirectionalIterator = (*namespaceIterator)(nil)
+
+// Prev moves the iterator backwards by one item.
+//
+// If the underlying iterator does not support moving backwards [corekv.ErrNotSupported]
+// will be returned.
+func (nIter *namespaceIterator) Prev() (bool, error) {
+	it, ok := nIter.it.(corekv.BidirectionalIterator)
+	if !ok {
+		return false, corekv.ErrNotSupported
+	}
+
+	return it.Prev()
+}
+
+// Peek returns the key of the next item without moving the iterator.
+//
+// If the underlying iterator does not support peeking [corekv.ErrNotSupported] will be
+// returned.
+func (nIter *namespaceIterator) Peek() ([]byte, bool, error) {
+	it, ok := nIter.it.(corekv.BidirectionalIterator)
+	if !ok {
+		return nil, false, corekv.ErrNotSupported
+	}
+
+	key, hasNext, err := it.Peek()
+	if err != nil || !hasNext {
+		return nil, hasNext, err
+	}
+
+	return key[len(nIter.namespace):], true, nil // strip namespace
+}
@@ -0,0 +1,64 @@
+package namespace
+
+import (
+	"context"
+
+	"github.com/sourcenetwork/corekv"
+)
+
+var _ corekv.ConditionalWriter = (*namespaceStore)(nil)
+
+// CompareAndSwap sets the value stored against the given namespaced key, if the currently
+// stored value is equal to expectedOld.
+//
+// If the underlying store does not support conditional writes [corekv.ErrNotSupported]
+// will be returned.
+func (nstore *namespaceStore) CompareAndSwap(ctx context.Context, key, expectedOld, newValue []byte) (bool, error) {
+	store, err := nstore.conditionalWriter(key)
+	if err != nil {
+		return false, err
+	}
+
+	return store.CompareAndSwap(ctx, nstore.prefixed(key), expectedOld, newValue)
+}
+
+// SetIfAbsent sets the value stored against the given namespaced key, if no item currently
+// exists at that key.
+//
+// If the underlying store does not support conditional writes [corekv.ErrNotSupported]
+// will be returned.
+func (nstore *namespaceStore) SetIfAbsent(ctx context.Context, key, value []byte) (bool, error) {
+	store, err := nstore.conditionalWriter(key)
+	if err != nil {
+		return false, err
+	}
+
+	return store.SetIfAbsent(ctx, nstore.prefixed(key), value)
+}
+
+// DeleteIfEquals removes the item at the given namespaced key, if the currently stored value
+// is equal to expected.
+//
+// If the underlying store does not support conditional writes [corekv.ErrNotSupported]
+// will be returned.
+func (nstore *namespaceStore) DeleteIfEquals(ctx context.Context, key, expected []byte) (bool, error) {
+	store, err := nstore.conditionalWriter(key)
+	if err != nil {
+		return false, err
+	}
+
+	return store.DeleteIfEquals(ctx, nstore.prefixed(key), expected)
+}
+
+func (nstore *namespaceStore) conditionalWriter(key []byte) (corekv.ConditionalWriter, error) {
+	if len(key) == 0 {
+		return nil, corekv.ErrEmptyKey
+	}
+
+	store, ok := nstore.store.(corekv.ConditionalWriter)
+	if !ok {
+		return nil, corekv.ErrNotSupported
+	}
+
+	return store, nil
+}
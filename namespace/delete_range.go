@@ -0,0 +1,56 @@
+package namespace
+
+import (
+	"context"
+
+	"github.com/sourcenetwork/corekv"
+)
+
+var _ corekv.RangeDeleter = (*namespaceStore)(nil)
+
+// DeleteRange removes all the items within the given range of the namespace.
+//
+// If start is nil items will be removed from the beginning of the namespace, if end
+// is nil items will be removed up to and including the end of the namespace.
+//
+// If the underlying store does not support range deletion [corekv.ErrNotSupported]
+// will be returned.
+func (nstore *namespaceStore) DeleteRange(ctx context.Context, start, end []byte) error {
+	store, ok := nstore.store.(corekv.RangeDeleter)
+	if !ok {
+		return corekv.ErrNotSupported
+	}
+
+	var pstart []byte
+	if start == nil {
+		pstart = cp(nstore.namespace)
+	} else {
+		pstart = nstore.prefixed(start)
+	}
+
+	var pend []byte
+	if end == nil {
+		pend = corekv.PrefixEnd(nstore.namespace)
+	} else {
+		pend = nstore.prefixed(end)
+	}
+
+	return store.DeleteRange(ctx, pstart, pend)
+}
+
+// DeletePrefix removes all the items with the given prefix within the namespace.
+//
+// If the underlying store does not support range deletion [corekv.ErrNotSupported]
+// will be returned.
+func (nstore *namespaceStore) DeletePrefix(ctx context.Context, prefix []byte) error {
+	if len(prefix) == 0 {
+		return corekv.ErrEmptyKey
+	}
+
+	store, ok := nstore.store.(corekv.RangeDeleter)
+	if !ok {
+		return corekv.ErrNotSupported
+	}
+
+	return store.DeletePrefix(ctx, nstore.prefixed(prefix))
+}
@@ -0,0 +1,56 @@
+package namespace
+
+import (
+	"context"
+
+	"github.com/sourcenetwork/corekv"
+)
+
+var _ corekv.MultiReader = (*namespaceRW)(nil)
+
+// GetMany returns the values at the given namespaced keys.
+//
+// If the underlying store or transaction does not support reading many items
+// [corekv.ErrNotSupported] will be returned.
+func (nstore *namespaceRW) GetMany(ctx context.Context, keys [][]byte) ([]corekv.GetResult, error) {
+	store, ok := nstore.rw.(corekv.MultiReader)
+	if !ok {
+		return nil, corekv.ErrNotSupported
+	}
+
+	pkeys, err := nstore.prefixedKeys(keys)
+	if err != nil {
+		return nil, err
+	}
+
+	return store.GetMany(ctx, pkeys)
+}
+
+// HasMany returns true for each of the given namespaced keys at which an item is found.
+//
+// If the underlying store or transaction does not support reading many items
+// [corekv.ErrNotSupported] will be returned.
+func (nstore *namespaceRW) HasMany(ctx context.Context, keys [][]byte) ([]bool, error) {
+	store, ok := nstore.rw.(corekv.MultiReader)
+	if !ok {
+		return nil, corekv.ErrNotSupported
+	}
+
+	pkeys, err := nstore.prefixedKeys(keys)
+	if err != nil {
+		return nil, err
+	}
+
+	return store.HasMany(ctx, pkeys)
+}
+
+func (nstore *namespaceRW) prefixedKeys(keys [][]byte) ([][]byte, error) {
+	pkeys := make([][]byte, len(keys))
+	for i, key := range keys {
+		if len(key) == 0 {
+			return nil, corekv.ErrEmptyKey
+		}
+		pkeys[i] = nstore.prefixed(key)
+	}
+	return pkeys, nil
+}
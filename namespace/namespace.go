@@ -6,74 +6,102 @@ import (
 	"github.com/sourcenetwork/corekv"
 )
 
+// readWriter is the set of functions shared by stores and transactions.
+type readWriter interface {
+	corekv.Reader
+	corekv.Writer
+}
+
+// namespaceRW wraps a namespace of a store or transaction, prefixing all keys
+// with the namespace.
+type namespaceRW struct {
+	namespace []byte
+	rw        readWriter
+}
+
 // namespaceStore wraps a namespace of another database as a logical database.
 type namespaceStore struct {
-	namespace []byte
-	store     corekv.Store
+	namespaceRW
+	store corekv.Store
 }
 
 var _ corekv.Store = (*namespaceStore)(nil)
 
 // Wrap lets you namespace a store with a given prefix.
-// The generic parameter allows you to wrap any kind of
-// underlying store ( corekv.TxnStore, Batchable, etc) and keep
-// the concrete type, just wrapped.
+//
+// If the given store is a [corekv.TxnStore] the returned store will also be a
+// [corekv.TxnStore], with all transactions created from it also namespaced.
+//
+// The returned store implements all the other optional interfaces, such as
+// [corekv.Batchable], returning [corekv.ErrNotSupported] from any that are not
+// implemented by the given store.
 func Wrap(store corekv.Store, prefix []byte) corekv.Store {
-	return &namespaceStore{
-		namespace: prefix,
-		store:     store,
+	nstore := &namespaceStore{
+		namespaceRW: namespaceRW{
+			namespace: prefix,
+			rw:        store,
+		},
+		store: store,
 	}
+
+	if _, ok := store.(corekv.TxnStore); ok {
+		return &namespaceTxnStore{
+			namespaceStore: nstore,
+		}
+	}
+
+	return nstore
 }
 
-func (nstore *namespaceStore) Get(ctx context.Context, key []byte) ([]byte, error) {
+func (nstore *namespaceRW) Get(ctx context.Context, key []byte) ([]byte, error) {
 	if len(key) == 0 {
 		return nil, corekv.ErrEmptyKey
 	}
 
 	pkey := nstore.prefixed(key)
-	value, err := nstore.store.Get(ctx, pkey)
+	value, err := nstore.rw.Get(ctx, pkey)
 	if err != nil {
 		return nil, err
 	}
 	return value, nil
 }
 
-func (nstore *namespaceStore) Has(ctx context.Context, key []byte) (bool, error) {
+func (nstore *namespaceRW) Has(ctx context.Context, key []byte) (bool, error) {
 	if len(key) == 0 {
 		return false, corekv.ErrEmptyKey
 	}
 	pkey := nstore.prefixed(key)
 
-	has, err := nstore.store.Has(ctx, pkey)
+	has, err := nstore.rw.Has(ctx, pkey)
 	if err != nil {
 		return false, err
 	}
 	return has, nil
 }
 
-func (nstore *namespaceStore) Set(ctx context.Context, key []byte, value []byte) error {
+func (nstore *namespaceRW) Set(ctx context.Context, key []byte, value []byte) error {
 	if len(key) == 0 {
 		return corekv.ErrEmptyKey
 	}
 	pkey := nstore.prefixed(key)
 
-	return nstore.store.Set(ctx, pkey, value)
+	return nstore.rw.Set(ctx, pkey, value)
 }
 
-func (nstore *namespaceStore) Delete(ctx context.Context, key []byte) error {
+func (nstore *namespaceRW) Delete(ctx context.Context, key []byte) error {
 	if len(key) == 0 {
 		return corekv.ErrEmptyKey
 	}
 	pkey := nstore.prefixed(key)
 
-	return nstore.store.Delete(ctx, pkey)
+	return nstore.rw.Delete(ctx, pkey)
 }
 
 func (nstore *namespaceStore) Close() error {
 	return nstore.store.Close()
 }
 
-func (nstore *namespaceStore) prefixed(key []byte) []byte {
+func (nstore *namespaceRW) prefixed(key []byte) []byte {
 	return prefixed(nstore.namespace, key)
 }
 
@@ -82,21 +110,31 @@ func prefixed(prefix, key []byte) []byte {
 }
 
 // Iterator creates a new iterator instance
-func (nstore *namespaceStore) Iterator(ctx context.Context, opts corekv.IterOptions) corekv.Iterator {
-	if opts.Prefix != nil {
-		opts.Prefix = nstore.prefixed(opts.Prefix)
-	} else {
-		if opts.Start != nil {
-			opts.Start = nstore.prefixed(opts.Start)
-		}
-		if opts.End != nil {
-			opts.End = nstore.prefixed(opts.End)
+func (nstore *namespaceRW) Iterator(ctx context.Context, opts corekv.IterOptions) corekv.Iterator {
+	// The namespace is always provided as a prefix, this ensures that the iterator cannot
+	// yield items outside of the namespace when no (or only one) bound is given.
+	opts.Prefix = nstore.prefixed(opts.Prefix)
+	if opts.Start != nil {
+		opts.Start = nstore.prefixed(opts.Start)
+	}
+	if opts.End != nil {
+		opts.End = nstore.prefixed(opts.End)
+	}
+	if opts.ResumeToken != nil {
+		// Resume tokens are created by callers using the keys yielded to them, so the key
+		// within the token must be namespaced before it reaches the underlying store.
+		//
+		// If the token is invalid it is passed through as-is and the underlying store will
+		// return the appropriate error.
+		key, reverse, err := corekv.ParseResumeToken(opts.ResumeToken)
+		if err == nil {
+			opts.ResumeToken = corekv.NewResumeToken(nstore.prefixed(key), reverse)
 		}
 	}
 
 	return &namespaceIterator{
 		namespace: nstore.namespace,
-		it:        nstore.store.Iterator(ctx, opts),
+		it:        nstore.rw.Iterator(ctx, opts),
 	}
 }
 
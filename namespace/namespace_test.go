@@ -0,0 +1,111 @@
+package namespace
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sourcenetwork/corekv"
+	"github.com/sourcenetwork/corekv/memory"
+	"github.com/stretchr/testify/require"
+)
+
+// plainStore hides all optional interfaces implemented by the wrapped store.
+type plainStore struct {
+	corekv.Store
+}
+
+// plainIterator hides the [corekv.BidirectionalIterator] implementation of the wrapped
+// iterator.
+type plainIterator struct {
+	corekv.Iterator
+}
+
+type plainIteratorStore struct {
+	corekv.Store
+}
+
+func (s *plainIteratorStore) Iterator(ctx context.Context, opts corekv.IterOptions) corekv.Iterator {
+	return &plainIterator{s.Store.Iterator(ctx, opts)}
+}
+
+func TestWrap_TxnStore(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewDatastore(ctx)
+	defer store.Close()
+
+	_, ok := Wrap(store, []byte("ns")).(corekv.TxnStore)
+	require.True(t, ok)
+
+	_, ok = Wrap(&plainStore{store}, []byte("ns")).(corekv.TxnStore)
+	require.False(t, ok)
+}
+
+func TestWrap_NoCapabilities_ReturnsNotSupported(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewDatastore(ctx)
+	defer store.Close()
+
+	nstore := Wrap(&plainStore{store}, []byte("ns"))
+	key := []byte("k1")
+
+	_, err := nstore.(corekv.Batchable).Batch(ctx)
+	require.ErrorIs(t, err, corekv.ErrNotSupported)
+
+	_, err = nstore.(corekv.ConditionalWriter).SetIfAbsent(ctx, key, []byte("v1"))
+	require.ErrorIs(t, err, corekv.ErrNotSupported)
+
+	err = nstore.(corekv.RangeDeleter).DeletePrefix(ctx, key)
+	require.ErrorIs(t, err, corekv.ErrNotSupported)
+
+	_, err = nstore.(corekv.Watchable).Watch(ctx, key)
+	require.ErrorIs(t, err, corekv.ErrNotSupported)
+
+	_, err = nstore.(corekv.MultiReader).GetMany(ctx, [][]byte{key})
+	require.ErrorIs(t, err, corekv.ErrNotSupported)
+
+	err = nstore.(corekv.TTLWriter).SetWithTTL(ctx, key, []byte("v1"), 0)
+	require.ErrorIs(t, err, corekv.ErrNotSupported)
+}
+
+func TestIterator_Unidirectional_ReturnsNotSupported(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewDatastore(ctx)
+	defer store.Close()
+
+	nstore := Wrap(&plainIteratorStore{store}, []byte("ns"))
+
+	it := nstore.Iterator(ctx, corekv.IterOptions{})
+	defer it.Close(ctx)
+
+	_, err := it.(corekv.BidirectionalIterator).Prev()
+	require.ErrorIs(t, err, corekv.ErrNotSupported)
+}
+
+func TestDeleteRange_MaximalNamespace(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewDatastore(ctx)
+	defer store.Close()
+
+	err := store.Set(ctx, []byte{0xfe}, []byte("outside"))
+	require.NoError(t, err)
+
+	nstore := Wrap(store, []byte{0xff, 0xff})
+	err = nstore.Set(ctx, []byte("k1"), []byte("v1"))
+	require.NoError(t, err)
+	err = nstore.Set(ctx, []byte{0xff}, []byte("v2"))
+	require.NoError(t, err)
+
+	err = nstore.(corekv.RangeDeleter).DeleteRange(ctx, nil, nil)
+	require.NoError(t, err)
+
+	has, err := nstore.Has(ctx, []byte("k1"))
+	require.NoError(t, err)
+	require.False(t, has)
+	has, err = nstore.Has(ctx, []byte{0xff})
+	require.NoError(t, err)
+	require.False(t, has)
+
+	has, err = store.Has(ctx, []byte{0xfe})
+	require.NoError(t, err)
+	require.True(t, has)
+}
@@ -0,0 +1,28 @@
+package namespace
+
+import (
+	"context"
+	"time"
+
+	"github.com/sourcenetwork/corekv"
+)
+
+var _ corekv.TTLWriter = (*namespaceRW)(nil)
+
+// SetWithTTL sets the value stored against the given namespaced key, the item will expire
+// once the given time-to-live has passed.
+//
+// If the underlying store or transaction does not support expiring items [corekv.ErrNotSupported]
+// will be returned.
+func (nstore *namespaceRW) SetWithTTL(ctx context.Context, key, value []byte, ttl time.Duration) error {
+	if len(key) == 0 {
+		return corekv.ErrEmptyKey
+	}
+
+	store, ok := nstore.rw.(corekv.TTLWriter)
+	if !ok {
+		return corekv.ErrNotSupported
+	}
+
+	return store.SetWithTTL(ctx, nstore.prefixed(key), value, ttl)
+}
@@ -0,0 +1,46 @@
+package namespace
+
+import (
+	"context"
+
+	"github.com/sourcenetwork/corekv"
+)
+
+// namespaceTxnStore wraps a namespace of another [corekv.TxnStore] as a logical
+// [corekv.TxnStore].
+type namespaceTxnStore struct {
+	*namespaceStore
+}
+
+var _ corekv.TxnStore = (*namespaceTxnStore)(nil)
+
+// NewTxn returns a new transaction from the underlying store, the keys of all
+// items read or written via the transaction will be namespaced.
+func (nstore *namespaceTxnStore) NewTxn(readonly bool) corekv.Txn {
+	txn := nstore.store.(corekv.TxnStore).NewTxn(readonly)
+
+	return &namespaceTxn{
+		namespaceRW: namespaceRW{
+			namespace: nstore.namespace,
+			rw:        txn,
+		},
+		txn: txn,
+	}
+}
+
+// namespaceTxn wraps a transaction of the underlying store, prefixing all keys
+// read or written via it with the namespace.
+type namespaceTxn struct {
+	namespaceRW
+	txn corekv.Txn
+}
+
+var _ corekv.Txn = (*namespaceTxn)(nil)
+
+func (ntxn *namespaceTxn) Commit(ctx context.Context) error {
+	return ntxn.txn.Commit(ctx)
+}
+
+func (ntxn *namespaceTxn) Discard(ctx context.Context) error {
+	return ntxn.txn.Discard(ctx)
+}
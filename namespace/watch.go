@@ -0,0 +1,48 @@
+package namespace
+
+import (
+	"context"
+
+	"github.com/sourcenetwork/corekv"
+)
+
+var _ corekv.Watchable = (*namespaceStore)(nil)
+
+// Watch returns a channel yielding the changes committed to items with the given prefix
+// within the namespace, the namespace will be stripped from the keys of the yielded events.
+//
+// If the underlying store does not support watching [corekv.ErrNotSupported] will be returned.
+func (nstore *namespaceStore) Watch(ctx context.Context, prefix []byte) (<-chan corekv.Event, error) {
+	store, ok := nstore.store.(corekv.Watchable)
+	if !ok {
+		return nil, corekv.ErrNotSupported
+	}
+
+	pEvents, err := store.Watch(ctx, nstore.prefixed(prefix))
+	if err != nil {
+		return nil, err
+	}
+
+	events := make(chan corekv.Event)
+	go func() {
+		defer close(events)
+
+		for event := range pEvents {
+			if event.Err == nil {
+				event.Key = event.Key[len(nstore.namespace):] // strip namespace
+			}
+
+			select {
+			case events <- event:
+			case <-ctx.Done():
+				// The underlying channel must still be drained until it closes, so that
+				// the underlying store is not blocked.
+				for range pEvents {
+				}
+				return
+			}
+		}
+	}()
+
+	return events, nil
+}
@@ -0,0 +1,118 @@
+package overlay
+
+import (
+	"context"
+
+	"github.com/sourcenetwork/corekv"
+	"github.com/sourcenetwork/corekv/merge"
+)
+
+// iterator yields the items of the base store merged with the changes held in the overlay.
+type iterator struct {
+	// The merged iterator, yielding encoded values from both stores.
+	it corekv.Iterator
+
+	keysOnly bool
+}
+
+var _ corekv.Iterator = (*iterator)(nil)
+
+func (s *Store) Iterator(ctx context.Context, opts corekv.IterOptions) corekv.Iterator {
+	// The limit and offset must be applied after tombstones have been skipped over, so they
+	// are not given to the underlying iterators.
+	sourceOpts := opts
+	sourceOpts.Limit = 0
+	sourceOpts.Offset = 0
+
+	baseIt := s.base.Iterator(ctx, sourceOpts)
+
+	// Values must be read from the overlay in order to know whether an item has been deleted.
+	sourceOpts.KeysOnly = false
+	overlayIt := s.overlay.Iterator(ctx, sourceOpts)
+
+	it := &iterator{
+		// The overlay is given first, so that its items take priority over the base store.
+		it:       merge.NewIterator(opts.Reverse, merge.FirstWins, overlayIt, &encodingIterator{baseIt}),
+		keysOnly: opts.KeysOnly,
+	}
+	return corekv.Paginate(it, opts)
+}
+
+func (iter *iterator) Reset() {
+	iter.it.Reset()
+}
+
+func (iter *iterator) Next() (bool, error) {
+	hasNext, err := iter.it.Next()
+	if err != nil || !hasNext {
+		return false, err
+	}
+	return iter.settle()
+}
+
+func (iter *iterator) Key() []byte {
+	return iter.it.Key()
+}
+
+func (iter *iterator) Value() ([]byte, error) {
+	if iter.keysOnly {
+		return nil, nil
+	}
+
+	value, err := iter.it.Value()
+	if err != nil {
+		return nil, err
+	}
+
+	value, _, err = decode(value)
+	return value, err
+}
+
+func (iter *iterator) Seek(key []byte) (bool, error) {
+	hasNext, err := iter.it.Seek(key)
+	if err != nil || !hasNext {
+		return false, err
+	}
+	return iter.settle()
+}
+
+func (iter *iterator) Close(ctx context.Context) error {
+	return iter.it.Close(ctx)
+}
+
+// settle moves the iterator past any deleted items.
+func (iter *iterator) settle() (bool, error) {
+	for {
+		value, err := iter.it.Value()
+		if err != nil {
+			return false, err
+		}
+
+		_, isDeleted, err := decode(value)
+		if err != nil {
+			return false, err
+		}
+		if !isDeleted {
+			return true, nil
+		}
+
+		hasNext, err := iter.it.Next()
+		if err != nil || !hasNext {
+			return false, err
+		}
+	}
+}
+
+// encodingIterator encodes the values of the base store as if they were held in the overlay,
+// allowing them to be merged with the overlay's values.
+type encodingIterator struct {
+	corekv.Iterator
+}
+
+func (iter *encodingIterator) Value() ([]byte, error) {
+	value, err := iter.Iterator.Value()
+	if err != nil {
+		return nil, err
+	}
+	return encode(value), nil
+}
@@ -0,0 +1,291 @@
+package overlay
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/sourcenetwork/corekv"
+)
+
+// Values held in the overlay are prefixed by one of these markers, allowing deletions
+// to be stored as tombstones, and nil values to be distinguished from empty ones.
+const (
+	tombstoneMarker byte = iota
+	nilMarker
+	valueMarker
+)
+
+// ErrInvalidValue is returned when reading a value from the overlay store that was not written
+// via a [Store], for example if the overlay store was written to directly.
+var ErrInvalidValue = errors.New("overlay: invalid value held in overlay store")
+
+// Store layers a writable overlay store over a base store.
+//
+// Reads fall through the overlay to the base store, writes and deletes land only in the
+// overlay until [Store.Flush] is called.
+type Store struct {
+	base    corekv.Store
+	overlay corekv.Store
+
+	// flushLk is held for writing whilst the overlay is being flushed or discarded, and for
+	// reading by all other writes.
+	flushLk sync.RWMutex
+}
+
+var _ corekv.Store = (*Store)(nil)
+
+// Wrap returns a new [*Store] that layers the given overlay store over the given base store.
+//
+// The base store will not be written to until [Store.Flush] is called.  The overlay store
+// should be empty, and must not be used directly whilst wrapped.
+func Wrap(base corekv.Store, overlay corekv.Store) *Store {
+	return &Store{
+		base:    base,
+		overlay: overlay,
+	}
+}
+
+// Base returns the base store.
+func (s *Store) Base() corekv.Store {
+	return s.base
+}
+
+func (s *Store) Get(ctx context.Context, key []byte) ([]byte, error) {
+	value, err := s.overlay.Get(ctx, key)
+	if errors.Is(err, corekv.ErrNotFound) {
+		return s.base.Get(ctx, key)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	value, isDeleted, err := decode(value)
+	if err != nil {
+		return nil, err
+	}
+	if isDeleted {
+		return nil, corekv.ErrNotFound
+	}
+	return value, nil
+}
+
+func (s *Store) Has(ctx context.Context, key []byte) (bool, error) {
+	value, err := s.overlay.Get(ctx, key)
+	if errors.Is(err, corekv.ErrNotFound) {
+		return s.base.Has(ctx, key)
+	}
+	if err != nil {
+		return false, err
+	}
+
+	_, isDeleted, err := decode(value)
+	if err != nil {
+		return false, err
+	}
+	return !isDeleted, nil
+}
+
+func (s *Store) Set(ctx context.Context, key []byte, value []byte) error {
+	if len(key) == 0 {
+		return corekv.ErrEmptyKey
+	}
+
+	s.flushLk.RLock()
+	defer s.flushLk.RUnlock()
+
+	return s.overlay.Set(ctx, key, encode(value))
+}
+
+// Delete removes the value at the given key from the view of this store, by writing a
+// tombstone to the overlay.
+func (s *Store) Delete(ctx context.Context, key []byte) error {
+	if len(key) == 0 {
+		return corekv.ErrEmptyKey
+	}
+
+	s.flushLk.RLock()
+	defer s.flushLk.RUnlock()
+
+	return s.overlay.Set(ctx, key, []byte{tombstoneMarker})
+}
+
+// Close closes the overlay store, discarding any changes that have not been flushed.
+//
+// The base store is not closed.
+func (s *Store) Close() error {
+	return s.overlay.Close()
+}
+
+// Flush applies all of the changes held in the overlay to the base store atomically, then
+// removes them from the overlay.
+//
+// The base store must be a [corekv.TxnStore] or a [corekv.Batchable], transactions are
+// preferred, if it is neither [corekv.ErrNotSupported] will be returned.  If the base store is
+// only [corekv.Batchable] the atomicity of the flush is limited to that of its batches.
+//
+// The changes are removed from the overlay using a single transaction or batch if the overlay
+// store supports them.  As the base and overlay stores are distinct, should the process fail
+// after the changes have been applied to the base store, but before they have been removed
+// from the overlay, they will remain in the overlay and be applied again by the next flush.
+func (s *Store) Flush(ctx context.Context) error {
+	s.flushLk.Lock()
+	defer s.flushLk.Unlock()
+
+	w, commit, discard, err := newWriter(ctx, s.base)
+	if err != nil {
+		return err
+	}
+
+	keys, err := s.apply(ctx, w)
+	if err != nil {
+		return errors.Join(err, discard(ctx))
+	}
+
+	err = commit(ctx)
+	if err != nil {
+		return err
+	}
+
+	return s.clear(ctx, keys)
+}
+
+// newWriter returns a writer whose writes are applied to the given store atomically once
+// committed, along with the functions to commit or discard it.
+//
+// Transactions are preferred over batches, if the store supports neither
+// [corekv.ErrNotSupported] will be returned.
+func newWriter(
+	ctx context.Context,
+	store corekv.Store,
+) (corekv.Writer, func(context.Context) error, func(context.Context) error, error) {
+	switch store := store.(type) {
+	case corekv.TxnStore:
+		txn := store.NewTxn(false)
+		return txn, txn.Commit, txn.Discard, nil
+
+	case corekv.Batchable:
+		batch, err := store.Batch(ctx)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		return batch, batch.Commit, batch.Discard, nil
+
+	default:
+		return nil, nil, nil, corekv.ErrNotSupported
+	}
+}
+
+// Discard removes all of the changes held in the overlay without applying them to the base
+// store.
+func (s *Store) Discard(ctx context.Context) error {
+	s.flushLk.Lock()
+	defer s.flushLk.Unlock()
+
+	keys, err := s.apply(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	return s.clear(ctx, keys)
+}
+
+// apply writes all of the changes held in the overlay to the given writer, returning the
+// keys of those changes.
+//
+// If the given writer is nil, the keys will be returned without writing anything.
+func (s *Store) apply(ctx context.Context, w corekv.Writer) (keys [][]byte, err error) {
+	it := s.overlay.Iterator(ctx, corekv.DefaultIterOptions)
+	defer func() {
+		err = errors.Join(err, it.Close(ctx))
+	}()
+
+	for {
+		hasNext, err := it.Next()
+		if err != nil {
+			return nil, err
+		}
+		if !hasNext {
+			return keys, nil
+		}
+
+		key := it.Key()
+		keys = append(keys, key)
+		if w == nil {
+			continue
+		}
+
+		value, err := it.Value()
+		if err != nil {
+			return nil, err
+		}
+
+		value, isDeleted, err := decode(value)
+		if err != nil {
+			return nil, err
+		}
+		if isDeleted {
+			err = w.Delete(ctx, key)
+		} else {
+			err = w.Set(ctx, key, value)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+// clear removes the given keys from the overlay.
+//
+// The keys are removed atomically if the overlay store supports transactions or batches.
+func (s *Store) clear(ctx context.Context, keys [][]byte) error {
+	w, commit, discard, err := newWriter(ctx, s.overlay)
+	if errors.Is(err, corekv.ErrNotSupported) {
+		w, commit, discard = s.overlay, noop, noop
+	} else if err != nil {
+		return err
+	}
+
+	for _, key := range keys {
+		err := w.Delete(ctx, key)
+		if err != nil {
+			return errors.Join(err, discard(ctx))
+		}
+	}
+	return commit(ctx)
+}
+
+func noop(context.Context) error {
+	return nil
+}
+
+func encode(value []byte) []byte {
+	if value == nil {
+		return []byte{nilMarker}
+	}
+
+	encoded := make([]byte, 0, len(value)+1)
+	encoded = append(encoded, valueMarker)
+	return append(encoded, value...)
+}
+
+// decode returns the value held in the given encoded overlay value, and whether it
+// represents a deletion.
+//
+// If the given value was not encoded by [encode] an [ErrInvalidValue] error is returned.
+func decode(encoded []byte) ([]byte, bool, error) {
+	if len(encoded) == 0 {
+		return nil, false, ErrInvalidValue
+	}
+
+	switch encoded[0] {
+	case tombstoneMarker:
+		return nil, true, nil
+	case nilMarker:
+		return nil, false, nil
+	case valueMarker:
+		return encoded[1:], false, nil
+	default:
+		return nil, false, ErrInvalidValue
+	}
+}
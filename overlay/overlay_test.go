@@ -0,0 +1,54 @@
+package overlay
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sourcenetwork/corekv"
+	"github.com/sourcenetwork/corekv/memory"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStore_InvalidOverlayValue_Errors(t *testing.T) {
+	for name, value := range map[string][]byte{
+		"empty":          {},
+		"unknown marker": {0xff, 0x01},
+	} {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			base := memory.NewDatastore(ctx)
+			defer base.Close()
+			overlayStore := memory.NewDatastore(ctx)
+
+			s := Wrap(base, overlayStore)
+			defer s.Close()
+
+			err := s.Set(ctx, []byte("k1"), []byte("v1"))
+			require.NoError(t, err)
+
+			// Values written directly to the overlay store were not encoded by the overlay.
+			err = overlayStore.Set(ctx, []byte("k2"), value)
+			require.NoError(t, err)
+
+			_, err = s.Get(ctx, []byte("k2"))
+			require.ErrorIs(t, err, ErrInvalidValue)
+
+			_, err = s.Has(ctx, []byte("k2"))
+			require.ErrorIs(t, err, ErrInvalidValue)
+
+			it := s.Iterator(ctx, corekv.IterOptions{Start: []byte("k2")})
+			_, err = it.Next()
+			require.ErrorIs(t, err, ErrInvalidValue)
+			err = it.Close(ctx)
+			require.NoError(t, err)
+
+			err = s.Flush(ctx)
+			require.ErrorIs(t, err, ErrInvalidValue)
+
+			// The flush must not have been partially applied.
+			has, err := base.Has(ctx, []byte("k1"))
+			require.NoError(t, err)
+			require.False(t, has)
+		})
+	}
+}
@@ -0,0 +1,233 @@
+package corekv
+
+import (
+	"context"
+)
+
+const (
+	resumeTokenVersion byte = 1
+
+	// resumeTokenReverse is set in the flags byte of tokens created by reverse iterators.
+	resumeTokenReverse byte = 1
+)
+
+// NewResumeToken returns an opaque token that may be provided as [IterOptions.ResumeToken]
+// in order to resume iteration from the item following the given key.
+//
+// The given key should be the last key yielded to the caller, and reverse should match the
+// [IterOptions.Reverse] value of the iterator that yielded it.
+func NewResumeToken(key []byte, reverse bool) []byte {
+	var flags byte
+	if reverse {
+		flags |= resumeTokenReverse
+	}
+
+	token := make([]byte, 0, len(key)+2)
+	token = append(token, resumeTokenVersion, flags)
+	return append(token, key...)
+}
+
+// ParseResumeToken returns the key and direction that the given token was created with.
+//
+// [ErrInvalidResumeToken] will be returned if the token is malformed.
+func ParseResumeToken(token []byte) (key []byte, reverse bool, err error) {
+	if len(token) < 3 || token[0] != resumeTokenVersion || token[1]&^resumeTokenReverse != 0 {
+		return nil, false, ErrInvalidResumeToken
+	}
+
+	return token[2:], token[1]&resumeTokenReverse != 0, nil
+}
+
+// Paginate returns an iterator that applies the `Limit` and `Offset` of the given options
+// to the given iterator.
+//
+// If neither are set the given iterator is returned as-is. It is intended for use by [Store]
+// implementations, the returned iterator will support the [BidirectionalIterator] methods
+// if the given iterator does.
+func Paginate(it Iterator, opts IterOptions) Iterator {
+	if opts.Limit <= 0 && opts.Offset <= 0 {
+		return it
+	}
+
+	return &pageIterator{
+		it:     it,
+		limit:  opts.Limit,
+		offset: opts.Offset,
+		reset:  true,
+	}
+}
+
+type pageIterator struct {
+	it     Iterator
+	limit  int
+	offset int
+
+	// The number of items yielded since the beginning of iteration, or since the last
+	// `Seek`, including the current item.
+	count int
+
+	// limited is true if the last `Next` call returned false due to the limit having been
+	// reached, in which case the underlying iterator remains on the last yielded item.
+	limited bool
+
+	// exhausted is true if the last `Next` call returned false due to the underlying
+	// iterator having been exhausted.
+	exhausted bool
+
+	// reset is a mutatuble property that indicates whether the iterator should be
+	// returned to the beginning on the next [Next] call.
+	reset bool
+}
+
+var _ BidirectionalIterator = (*pageIterator)(nil)
+
+func (p *pageIterator) Reset() {
+	p.reset = true
+	p.it.Reset()
+}
+
+func (p *pageIterator) Next() (bool, error) {
+	if p.reset {
+		p.reset = false
+		p.count = 0
+		p.limited = false
+		p.exhausted = false
+
+		for i := 0; i < p.offset; i++ {
+			hasNext, err := p.it.Next()
+			if err != nil {
+				return false, err
+			}
+			if !hasNext {
+				p.exhausted = true
+				return false, nil
+			}
+		}
+	}
+
+	if p.limited || p.exhausted {
+		return false, nil
+	}
+	if p.limit > 0 && p.count >= p.limit {
+		p.limited = true
+		return false, nil
+	}
+
+	hasNext, err := p.it.Next()
+	if err != nil {
+		return false, err
+	}
+	if !hasNext {
+		p.exhausted = true
+		return false, nil
+	}
+
+	p.count++
+	return true, nil
+}
+
+func (p *pageIterator) Key() []byte {
+	return p.it.Key()
+}
+
+func (p *pageIterator) Value() ([]byte, error) {
+	return p.it.Value()
+}
+
+func (p *pageIterator) Seek(key []byte) (bool, error) {
+	p.reset = false
+	p.limited = false
+	p.exhausted = false
+	p.count = 0
+
+	hasValue, err := p.it.Seek(key)
+	if err != nil || !hasValue {
+		return false, err
+	}
+
+	p.count = 1
+	return true, nil
+}
+
+// Prev moves the iterator backwards by one item, it will not move before the first item
+// yielded since the beginning of iteration, or since the last `Seek`.
+//
+// If the underlying iterator does not support moving backwards [ErrNotSupported] will be
+// returned.
+func (p *pageIterator) Prev() (bool, error) {
+	it, ok := p.it.(BidirectionalIterator)
+	if !ok {
+		return false, ErrNotSupported
+	}
+
+	if p.reset {
+		return false, nil
+	}
+
+	if p.limited {
+		// The underlying iterator was not moved when the limit was reached, so it is still
+		// on the last yielded item.
+		p.limited = false
+		return true, nil
+	}
+
+	if p.exhausted {
+		// Moving backwards from beyond the last item will return the underlying iterator
+		// to the last yielded item.
+		p.exhausted = false
+		if p.count == 0 {
+			p.Reset()
+			return false, nil
+		}
+		return it.Prev()
+	}
+
+	if p.count <= 1 {
+		p.Reset()
+		return false, nil
+	}
+
+	hasPrev, err := it.Prev()
+	if err != nil || !hasPrev {
+		return false, err
+	}
+
+	p.count--
+	return true, nil
+}
+
+// Peek returns the key of the next item without moving the iterator.
+//
+// If the underlying iterator does not support peeking [ErrNotSupported] will be returned.
+func (p *pageIterator) Peek() ([]byte, bool, error) {
+	it, ok := p.it.(BidirectionalIterator)
+	if !ok {
+		return nil, false, ErrNotSupported
+	}
+
+	if p.reset {
+		if p.offset > 0 {
+			// The offset can only be applied by moving the underlying iterator, so we
+			// must move it and then return it to the beginning.
+			hasNext, err := p.Next()
+			var key []byte
+			if hasNext {
+				key = p.Key()
+			}
+			p.Reset()
+			return key, hasNext, err
+		}
+
+		return it.Peek()
+	}
+
+	if p.limited || p.exhausted || (p.limit > 0 && p.count >= p.limit) {
+		return nil, false, nil
+	}
+
+	return it.Peek()
+}
+
+func (p *pageIterator) Close(ctx context.Context) error {
+	return p.it.Close(ctx)
+}
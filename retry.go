@@ -0,0 +1,109 @@
+package corekv
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"time"
+)
+
+const (
+	// DefaultMaxAttempts is the number of attempts made by [RunInTxn] if
+	// [RetryOptions.MaxAttempts] is not set.
+	DefaultMaxAttempts = 10
+	// DefaultInitialBackoff is the delay before the first retry made by [RunInTxn] if
+	// [RetryOptions.InitialBackoff] is not set.
+	DefaultInitialBackoff = time.Millisecond
+	// DefaultMaxBackoff is the maximum delay between retries made by [RunInTxn] if
+	// [RetryOptions.MaxBackoff] is not set.
+	DefaultMaxBackoff = 100 * time.Millisecond
+)
+
+// RetryOptions configure the behaviour of [RunInTxn].
+//
+// Zero values are replaced with their defaults.
+type RetryOptions struct {
+	// ReadOnly, if true, will run the function within read only transactions.
+	ReadOnly bool
+
+	// MaxAttempts is the maximum number of times the function will be run.
+	//
+	// Defaults to [DefaultMaxAttempts].
+	MaxAttempts int
+
+	// InitialBackoff is the delay before the first retry, it doubles with each subsequent
+	// retry up to [RetryOptions.MaxBackoff].
+	//
+	// A random jitter of up to half of the delay is subtracted from each delay so that
+	// conflicting callers do not retry in lockstep.
+	//
+	// Defaults to [DefaultInitialBackoff].
+	InitialBackoff time.Duration
+
+	// MaxBackoff is the maximum delay between retries.
+	//
+	// Defaults to [DefaultMaxBackoff].
+	MaxBackoff time.Duration
+}
+
+// RunInTxn runs the given function within a new transaction, committing it if the function
+// succeeds.
+//
+// If either the function or the commit fails with an [ErrTxnConflict] error the transaction
+// is discarded and the function is run again within a new transaction, after a backoff
+// delay, until [RetryOptions.MaxAttempts] is reached. The last error is returned if all
+// attempts fail.
+//
+// Any other error will discard the transaction and be returned immediately.
+func RunInTxn(ctx context.Context, store TxnStore, fn func(Txn) error, opts RetryOptions) error {
+	maxAttempts := opts.MaxAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = DefaultMaxAttempts
+	}
+	backoff := opts.InitialBackoff
+	if backoff <= 0 {
+		backoff = DefaultInitialBackoff
+	}
+	maxBackoff := opts.MaxBackoff
+	if maxBackoff <= 0 {
+		maxBackoff = DefaultMaxBackoff
+	}
+
+	var err error
+	for attempt := 1; ; attempt++ {
+		err = runTxnAttempt(ctx, store, fn, opts.ReadOnly)
+		if err == nil || !errors.Is(err, ErrTxnConflict) || attempt >= maxAttempts {
+			return err
+		}
+
+		if backoff > maxBackoff {
+			backoff = maxBackoff
+		}
+		delay := backoff - time.Duration(rand.Int63n(int64(backoff/2)+1))
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return errors.Join(ctx.Err(), err)
+		case <-timer.C:
+		}
+
+		backoff *= 2
+	}
+}
+
+// runTxnAttempt runs the given function within a new transaction, discarding it if either
+// the function or the commit fails.
+func runTxnAttempt(ctx context.Context, store TxnStore, fn func(Txn) error, readOnly bool) error {
+	txn := store.NewTxn(readOnly)
+
+	err := fn(txn)
+	if err == nil {
+		err = txn.Commit(ctx)
+	}
+	if err != nil {
+		return errors.Join(err, txn.Discard(ctx))
+	}
+	return nil
+}
@@ -0,0 +1,105 @@
+//go:build go1.23
+
+package corekv
+
+import (
+	"context"
+	"errors"
+	"iter"
+)
+
+// SeqErr provides access to any error encountered whilst ranging over a sequence
+// returned by [All], [Keys], [Scan] or [ScanKeys].
+//
+// It should be checked once ranging over the sequence has completed.
+type SeqErr func() error
+
+// All returns a sequence of the key-value pairs yielded by the given iterator, and an
+// accessor to any error encountered whilst ranging over it.
+//
+// The iterator will be closed once ranging has completed, including if the loop is
+// exited early, as such the returned sequence may only be ranged over once.
+func All(ctx context.Context, it Iterator) (iter.Seq2[[]byte, []byte], SeqErr) {
+	var err error
+	seq := func(yield func([]byte, []byte) bool) {
+		err = rangeIter(ctx, it, func(key []byte) (bool, error) {
+			value, err := it.Value()
+			if err != nil {
+				return false, err
+			}
+			return yield(key, value), nil
+		})
+	}
+	return seq, func() error { return err }
+}
+
+// Keys returns a sequence of the keys yielded by the given iterator, and an accessor to any
+// error encountered whilst ranging over it.
+//
+// Values are not read from the iterator.
+//
+// The iterator will be closed once ranging has completed, including if the loop is
+// exited early, as such the returned sequence may only be ranged over once.
+func Keys(ctx context.Context, it Iterator) (iter.Seq[[]byte], SeqErr) {
+	var err error
+	seq := func(yield func([]byte) bool) {
+		err = rangeIter(ctx, it, func(key []byte) (bool, error) {
+			return yield(key), nil
+		})
+	}
+	return seq, func() error { return err }
+}
+
+// Scan returns a sequence of the key-value pairs within the given reader matching the given
+// options, and an accessor to any error encountered whilst ranging over it.
+//
+// A new iterator is created each time the sequence is ranged over, and closed once that
+// ranging has completed.
+func Scan(ctx context.Context, r Reader, opts IterOptions) (iter.Seq2[[]byte, []byte], SeqErr) {
+	var err error
+	seq := func(yield func([]byte, []byte) bool) {
+		all, allErr := All(ctx, r.Iterator(ctx, opts))
+		all(yield)
+		err = allErr()
+	}
+	return seq, func() error { return err }
+}
+
+// ScanKeys returns a sequence of the keys within the given reader matching the given
+// options, and an accessor to any error encountered whilst ranging over it.
+//
+// A new keys-only iterator is created each time the sequence is ranged over, and closed
+// once that ranging has completed.
+func ScanKeys(ctx context.Context, r Reader, opts IterOptions) (iter.Seq[[]byte], SeqErr) {
+	opts.KeysOnly = true
+
+	var err error
+	seq := func(yield func([]byte) bool) {
+		keys, keysErr := Keys(ctx, r.Iterator(ctx, opts))
+		keys(yield)
+		err = keysErr()
+	}
+	return seq, func() error { return err }
+}
+
+// rangeIter calls the given function for each key yielded by the given iterator, until
+// either the iterator is exhausted, an error is returned, or the function returns false.
+//
+// The iterator is always closed before returning.
+func rangeIter(ctx context.Context, it Iterator, f func(key []byte) (bool, error)) (err error) {
+	defer func() {
+		err = errors.Join(err, it.Close(ctx))
+	}()
+
+	for {
+		hasNext, err := it.Next()
+		if err != nil || !hasNext {
+			return err
+		}
+
+		cont, err := f(it.Key())
+		if err != nil || !cont {
+			return err
+		}
+	}
+}
@@ -0,0 +1,143 @@
+package action
+
+import (
+	"github.com/sourcenetwork/corekv"
+	"github.com/sourcenetwork/corekv/test/state"
+	"github.com/stretchr/testify/require"
+)
+
+// CreateBatch action will create a new batch from the active store when executed.
+//
+// The active store must be a [corekv.Batchable].  Created batches are referenced by
+// their index, in order of creation, starting at zero.
+type CreateBatch struct{}
+
+var _ Action = (*CreateBatch)(nil)
+
+// NewBatch returns a new [*CreateBatch] action that will create a new batch from the
+// active store when executed.
+func NewBatch() *CreateBatch {
+	return &CreateBatch{}
+}
+
+func (a *CreateBatch) Execute(s *state.State) {
+	store, ok := s.Store.(corekv.Batchable)
+	require.True(s.T, ok, "store does not support batches")
+
+	batch, err := store.Batch(s.Ctx)
+	require.NoError(s.T, err)
+
+	s.Batches = append(s.Batches, batch)
+}
+
+// BatchSetValue action will set the given key to the given value within the batch
+// at the given index when executed.
+type BatchSetValue struct {
+	BatchID       int
+	Key           []byte
+	Value         []byte
+	ExpectedError string
+}
+
+var _ Action = (*BatchSetValue)(nil)
+
+// BatchSet returns a new [*BatchSetValue] action that will set the given key to the given
+// value within the batch at the given index when executed.
+func BatchSet(batchID int, key []byte, value []byte) *BatchSetValue {
+	return &BatchSetValue{
+		BatchID: batchID,
+		Key:     key,
+		Value:   value,
+	}
+}
+
+// BatchSetE returns a new [*BatchSetValue] action that will set the given key to the given
+// value within the batch at the given index when executed, and require that the returned
+// error contains the given string.
+func BatchSetE(batchID int, key []byte, value []byte, expectedErr string) *BatchSetValue {
+	return &BatchSetValue{
+		BatchID:       batchID,
+		Key:           key,
+		Value:         value,
+		ExpectedError: expectedErr,
+	}
+}
+
+func (a *BatchSetValue) Execute(s *state.State) {
+	err := s.Batches[a.BatchID].Set(s.Ctx, a.Key, a.Value)
+	expectError(s, err, a.ExpectedError)
+}
+
+// BatchDeleteValue action will delete the entry at the given key within the batch
+// at the given index when executed.
+type BatchDeleteValue struct {
+	BatchID       int
+	Key           []byte
+	ExpectedError string
+}
+
+var _ Action = (*BatchDeleteValue)(nil)
+
+// BatchDelete returns a new [*BatchDeleteValue] action that will delete the entry at the
+// given key within the batch at the given index when executed.
+func BatchDelete(batchID int, key []byte) *BatchDeleteValue {
+	return &BatchDeleteValue{
+		BatchID: batchID,
+		Key:     key,
+	}
+}
+
+func (a *BatchDeleteValue) Execute(s *state.State) {
+	err := s.Batches[a.BatchID].Delete(s.Ctx, a.Key)
+	expectError(s, err, a.ExpectedError)
+}
+
+// CommitBatch action will commit the batch at the given index when executed.
+type CommitBatch struct {
+	BatchID       int
+	ExpectedError string
+}
+
+var _ Action = (*CommitBatch)(nil)
+
+// BatchCommit returns a new [*CommitBatch] action that will commit the batch at the given
+// index when executed.
+func BatchCommit(batchID int) *CommitBatch {
+	return &CommitBatch{
+		BatchID: batchID,
+	}
+}
+
+// BatchCommitE returns a new [*CommitBatch] action that will commit the batch at the given
+// index when executed and require that the returned error contains the given string.
+func BatchCommitE(batchID int, expectedErr string) *CommitBatch {
+	return &CommitBatch{
+		BatchID:       batchID,
+		ExpectedError: expectedErr,
+	}
+}
+
+func (a *CommitBatch) Execute(s *state.State) {
+	err := s.Batches[a.BatchID].Commit(s.Ctx)
+	expectError(s, err, a.ExpectedError)
+}
+
+// DiscardBatch action will discard the batch at the given index when executed.
+type DiscardBatch struct {
+	BatchID int
+}
+
+var _ Action = (*DiscardBatch)(nil)
+
+// BatchDiscard returns a new [*DiscardBatch] action that will discard the batch at the given
+// index when executed.
+func BatchDiscard(batchID int) *DiscardBatch {
+	return &DiscardBatch{
+		BatchID: batchID,
+	}
+}
+
+func (a *DiscardBatch) Execute(s *state.State) {
+	err := s.Batches[a.BatchID].Discard(s.Ctx)
+	require.NoError(s.T, err)
+}
@@ -0,0 +1,54 @@
+package action
+
+import (
+	"github.com/sourcenetwork/corekv/cache"
+	"github.com/sourcenetwork/corekv/test/state"
+	"github.com/stretchr/testify/require"
+)
+
+// CacheStore action will wrap the current store (replacing it) in a read-through cache
+// when executed.
+type CacheStore struct {
+	MaxCost int64
+}
+
+var _ Action = (*CacheStore)(nil)
+
+// Cache returns a new [*CacheStore] action that will wrap the current store in a
+// read-through cache, holding up to the given cost of entries, when executed.
+func Cache(maxCost int64) *CacheStore {
+	return &CacheStore{
+		MaxCost: maxCost,
+	}
+}
+
+func (a *CacheStore) Execute(s *state.State) {
+	var opts []cache.Option
+	if s.Clock != nil {
+		opts = append(opts, cache.WithClock(s.Clock.Now))
+	}
+	s.Store = cache.Wrap(s.Store, a.MaxCost, opts...)
+}
+
+// CacheStats action will require that the statistics of the current cache store match
+// the expected statistics when executed.
+type CacheStats struct {
+	Expected cache.Stats
+}
+
+var _ Action = (*CacheStats)(nil)
+
+// Stats returns a new [*CacheStats] action that will require that the statistics of the
+// current cache store match the given statistics when executed.
+func Stats(expected cache.Stats) *CacheStats {
+	return &CacheStats{
+		Expected: expected,
+	}
+}
+
+func (a *CacheStats) Execute(s *state.State) {
+	store, ok := s.Store.(cache.Cache)
+	require.True(s.T, ok, "store is not a cache")
+
+	require.Equal(s.T, a.Expected, store.Stats())
+}
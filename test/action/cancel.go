@@ -1,7 +1,11 @@
 package action
 
 import (
+	"context"
+
+	"github.com/sourcenetwork/corekv"
 	"github.com/sourcenetwork/corekv/test/state"
+	"github.com/stretchr/testify/require"
 )
 
 // CancelCtx action will cancel the state's context when executed.
@@ -17,3 +21,87 @@ func Cancel() *CancelCtx {
 func (a *CancelCtx) Execute(s *state.State) {
 	s.CtxCancel()
 }
+
+// CancelIteration action will iterate through the active store using a new cancellable
+// context, cancelling it part way through the iteration, when executed.
+//
+// Once cancelled the iterator is required to return the context's error when moved.
+type CancelIteration struct {
+	corekv.IterOptions
+
+	// The number of items to yield before cancelling the context.
+	CancelAfter int
+
+	// The items expected to be yielded before the context is cancelled.
+	//
+	// Matching element order is required.
+	Expected []KeyValue
+}
+
+var _ Action = (*CancelIteration)(nil)
+
+func (a *CancelIteration) Execute(s *state.State) {
+	ctx, cancel := context.WithCancel(s.Ctx)
+	defer cancel()
+
+	iterator := s.Store.Iterator(ctx, a.IterOptions)
+
+	entries := []KeyValue{}
+	for i := 0; i < a.CancelAfter; i++ {
+		hasValue, err := iterator.Next()
+		require.NoError(s.T, err)
+		require.True(s.T, hasValue)
+
+		value, err := iterator.Value()
+		require.NoError(s.T, err)
+
+		entries = append(entries, KeyValue{
+			Key:   iterator.Key(),
+			Value: value,
+		})
+	}
+	require.Equal(s.T, a.Expected, entries)
+
+	cancel()
+
+	hasValue, err := iterator.Next()
+	require.ErrorIs(s.T, err, context.Canceled)
+	require.False(s.T, hasValue)
+
+	hasValue, err = iterator.Seek(a.Start)
+	require.ErrorIs(s.T, err, context.Canceled)
+	require.False(s.T, hasValue)
+
+	err = iterator.Close(s.Ctx)
+	require.NoError(s.T, err)
+}
+
+// CancelledCtxAction will execute the given child action using a cancelled context, instead
+// of the state's context, when executed.
+type CancelledCtxAction struct {
+	// The action to execute.
+	Action Action
+}
+
+var _ Action = (*CancelledCtxAction)(nil)
+
+// WithCancelledCtx returns a new [*CancelledCtxAction] action that will execute the given
+// child action using a cancelled context when executed.
+func WithCancelledCtx(action Action) *CancelledCtxAction {
+	return &CancelledCtxAction{
+		Action: action,
+	}
+}
+
+func (a *CancelledCtxAction) Execute(s *state.State) {
+	ctx := s.Ctx
+	defer func() {
+		s.Ctx = ctx
+	}()
+
+	cancelledCtx, cancel := context.WithCancel(ctx)
+	cancel()
+
+	s.Ctx = cancelledCtx
+	a.Action.Execute(s)
+}
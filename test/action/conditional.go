@@ -0,0 +1,119 @@
+package action
+
+import (
+	"github.com/sourcenetwork/corekv"
+	"github.com/sourcenetwork/corekv/test/state"
+	"github.com/stretchr/testify/require"
+)
+
+// CompareAndSwapValue action will set the given key to the given new value, if the current
+// value matches the given old value, when executed.
+type CompareAndSwapValue struct {
+	Key      []byte
+	Old      []byte
+	New      []byte
+	Expected bool
+
+	ExpectedError string
+}
+
+var _ Action = (*CompareAndSwapValue)(nil)
+
+// CompareAndSwap returns a new [*CompareAndSwapValue] action that will set the given key to the
+// given new value, if the current value matches the given old value, when executed. It requires
+// that the result of the call matches the given expected value.
+func CompareAndSwap(key, oldValue, newValue []byte, expected bool) *CompareAndSwapValue {
+	return &CompareAndSwapValue{
+		Key:      key,
+		Old:      oldValue,
+		New:      newValue,
+		Expected: expected,
+	}
+}
+
+func (a *CompareAndSwapValue) Execute(s *state.State) {
+	store, ok := s.Store.(corekv.ConditionalWriter)
+	require.True(s.T, ok, "store does not support conditional writes")
+
+	actual, err := store.CompareAndSwap(s.Ctx, a.Key, a.Old, a.New)
+	expectError(s, err, a.ExpectedError)
+
+	require.Equal(s.T, a.Expected, actual)
+}
+
+// SetIfAbsentValue action will set the given key to the given value, if no value currently
+// exists at that key, when executed.
+type SetIfAbsentValue struct {
+	Key      []byte
+	Value    []byte
+	Expected bool
+
+	ExpectedError string
+}
+
+var _ Action = (*SetIfAbsentValue)(nil)
+
+// SetIfAbsent returns a new [*SetIfAbsentValue] action that will set the given key to the given
+// value, if no value currently exists at that key, when executed. It requires that the result
+// of the call matches the given expected value.
+func SetIfAbsent(key, value []byte, expected bool) *SetIfAbsentValue {
+	return &SetIfAbsentValue{
+		Key:      key,
+		Value:    value,
+		Expected: expected,
+	}
+}
+
+// SetIfAbsentE returns a new [*SetIfAbsentValue] action that will set the given key to the given
+// value, if no value currently exists at that key, when executed and requires that the returned
+// error contains the given string.
+func SetIfAbsentE(key, value []byte, expectedErr string) *SetIfAbsentValue {
+	return &SetIfAbsentValue{
+		Key:           key,
+		Value:         value,
+		ExpectedError: expectedErr,
+	}
+}
+
+func (a *SetIfAbsentValue) Execute(s *state.State) {
+	store, ok := s.Store.(corekv.ConditionalWriter)
+	require.True(s.T, ok, "store does not support conditional writes")
+
+	actual, err := store.SetIfAbsent(s.Ctx, a.Key, a.Value)
+	expectError(s, err, a.ExpectedError)
+
+	require.Equal(s.T, a.Expected, actual)
+}
+
+// DeleteIfEqualsValue action will delete the entry at the given key, if the current value
+// matches the given value, when executed.
+type DeleteIfEqualsValue struct {
+	Key      []byte
+	Value    []byte
+	Expected bool
+
+	ExpectedError string
+}
+
+var _ Action = (*DeleteIfEqualsValue)(nil)
+
+// DeleteIfEquals returns a new [*DeleteIfEqualsValue] action that will delete the entry at the
+// given key, if the current value matches the given value, when executed. It requires that the
+// result of the call matches the given expected value.
+func DeleteIfEquals(key, value []byte, expected bool) *DeleteIfEqualsValue {
+	return &DeleteIfEqualsValue{
+		Key:      key,
+		Value:    value,
+		Expected: expected,
+	}
+}
+
+func (a *DeleteIfEqualsValue) Execute(s *state.State) {
+	store, ok := s.Store.(corekv.ConditionalWriter)
+	require.True(s.T, ok, "store does not support conditional writes")
+
+	actual, err := store.DeleteIfEquals(s.Ctx, a.Key, a.Value)
+	expectError(s, err, a.ExpectedError)
+
+	require.Equal(s.T, a.Expected, actual)
+}
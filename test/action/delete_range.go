@@ -0,0 +1,77 @@
+package action
+
+import (
+	"github.com/sourcenetwork/corekv"
+	"github.com/sourcenetwork/corekv/test/state"
+	"github.com/stretchr/testify/require"
+)
+
+// DeleteRangeValues action deletes all the entries within the given range when executed.
+type DeleteRangeValues struct {
+	Start         []byte
+	End           []byte
+	ExpectedError string
+}
+
+var _ Action = (*DeleteRangeValues)(nil)
+
+// DeleteRange returns a new [*DeleteRangeValues] action that deletes all the entries with
+// keys greater than or equal to start, and smaller than end, when executed.
+func DeleteRange(start, end []byte) *DeleteRangeValues {
+	return &DeleteRangeValues{
+		Start: start,
+		End:   end,
+	}
+}
+
+// DeleteRangeE returns a new [*DeleteRangeValues] action that attempts to delete all the
+// entries within the given range, and requires that the returned error contains the given
+// string.
+func DeleteRangeE(start, end []byte, expectedErr string) *DeleteRangeValues {
+	return &DeleteRangeValues{
+		Start:         start,
+		End:           end,
+		ExpectedError: expectedErr,
+	}
+}
+
+func (a *DeleteRangeValues) Execute(s *state.State) {
+	store, ok := s.Store.(corekv.RangeDeleter)
+	require.True(s.T, ok, "store does not support range deletion")
+
+	err := store.DeleteRange(s.Ctx, a.Start, a.End)
+	expectError(s, err, a.ExpectedError)
+}
+
+// DeletePrefixValues action deletes all the entries with the given prefix when executed.
+type DeletePrefixValues struct {
+	Prefix        []byte
+	ExpectedError string
+}
+
+var _ Action = (*DeletePrefixValues)(nil)
+
+// DeletePrefix returns a new [*DeletePrefixValues] action that deletes all the entries with
+// the given prefix when executed.
+func DeletePrefix(prefix []byte) *DeletePrefixValues {
+	return &DeletePrefixValues{
+		Prefix: prefix,
+	}
+}
+
+// DeletePrefixE returns a new [*DeletePrefixValues] action that deletes all the entries with
+// the given prefix when executed, and requires that the returned error contains the given string.
+func DeletePrefixE(prefix []byte, expectedErr string) *DeletePrefixValues {
+	return &DeletePrefixValues{
+		Prefix:        prefix,
+		ExpectedError: expectedErr,
+	}
+}
+
+func (a *DeletePrefixValues) Execute(s *state.State) {
+	store, ok := s.Store.(corekv.RangeDeleter)
+	require.True(s.T, ok, "store does not support range deletion")
+
+	err := store.DeletePrefix(s.Ctx, a.Prefix)
+	expectError(s, err, a.ExpectedError)
+}
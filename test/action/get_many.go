@@ -0,0 +1,75 @@
+package action
+
+import (
+	"github.com/sourcenetwork/corekv"
+	"github.com/sourcenetwork/corekv/test/state"
+	"github.com/stretchr/testify/require"
+)
+
+// GetManyValues action will get the values at the given keys when executed, and require
+// that the returned results match the given expected results.
+type GetManyValues struct {
+	Keys          [][]byte
+	Expected      []corekv.GetResult
+	ExpectedError string
+}
+
+var _ Action = (*GetManyValues)(nil)
+
+// GetMany returns a new [*GetManyValues] action that will get the values at the given keys
+// when executed, and require that the returned results match the given expected results.
+func GetMany(keys [][]byte, expected []corekv.GetResult) *GetManyValues {
+	return &GetManyValues{
+		Keys:     keys,
+		Expected: expected,
+	}
+}
+
+// GetManyE returns a new [*GetManyValues] action that will get the values at the given keys
+// when executed, and require that the returned error contains the given string.
+func GetManyE(keys [][]byte, expectedErr string) *GetManyValues {
+	return &GetManyValues{
+		Keys:          keys,
+		ExpectedError: expectedErr,
+	}
+}
+
+func (a *GetManyValues) Execute(s *state.State) {
+	store, ok := s.Store.(corekv.MultiReader)
+	require.True(s.T, ok, "store does not support reading many items")
+
+	actual, err := store.GetMany(s.Ctx, a.Keys)
+	expectError(s, err, a.ExpectedError)
+
+	require.Equal(s.T, a.Expected, actual)
+}
+
+// HasManyValues action will check whether the store has values at the given keys when
+// executed, and require that the returned results match the given expected results.
+type HasManyValues struct {
+	Keys          [][]byte
+	Expected      []bool
+	ExpectedError string
+}
+
+var _ Action = (*HasManyValues)(nil)
+
+// HasMany returns a new [*HasManyValues] action that will check whether the store has values
+// at the given keys when executed, and require that the returned results match the given
+// expected results.
+func HasMany(keys [][]byte, expected []bool) *HasManyValues {
+	return &HasManyValues{
+		Keys:     keys,
+		Expected: expected,
+	}
+}
+
+func (a *HasManyValues) Execute(s *state.State) {
+	store, ok := s.Store.(corekv.MultiReader)
+	require.True(s.T, ok, "store does not support reading many items")
+
+	actual, err := store.HasMany(s.Ctx, a.Keys)
+	expectError(s, err, a.ExpectedError)
+
+	require.Equal(s.T, a.Expected, actual)
+}
@@ -34,25 +34,37 @@ var _ Action = (*Iterate)(nil)
 func (a *Iterate) Execute(s *state.State) {
 	iterator := s.Store.Iterator(s.Ctx, a.IterOptions)
 
+	entries, err := iterate(iterator)
+	expectError(s, err, a.ExpectedError)
+
+	err = iterator.Close(s.Ctx)
+	require.NoError(s.T, err)
+
+	require.Equal(s.T, a.Expected, entries)
+}
+
+// iterate moves through all the items of the given iterator, returning them.
+//
+// If an error is encountered the items yielded up until that point will be returned
+// alongside the error.
+func iterate(iterator corekv.Iterator) ([]KeyValue, error) {
 	entries := make([]KeyValue, 0)
 	for {
 		hasValue, err := iterator.Next()
-		require.NoError(s.T, err)
-
-		if !hasValue {
-			break
+		if err != nil || !hasValue {
+			return entries, err
 		}
 
 		key := iterator.Key()
 
 		value, err := iterator.Value()
-		expectError(s, err, a.ExpectedError)
+		if err != nil {
+			return entries, err
+		}
 
 		entries = append(entries, KeyValue{
 			Key:   key,
 			Value: value,
 		})
 	}
-
-	require.Equal(s.T, a.Expected, entries)
 }
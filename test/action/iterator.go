@@ -118,3 +118,83 @@ func Reset() *IteratorReset {
 func (a *IteratorReset) Execute(s *state.State, iterator corekv.Iterator) {
 	iterator.Reset()
 }
+
+// IteratorKey executes a single `Key` call on an [Iterator] and requires that
+// the returned result matches the given `Expected` key.
+type IteratorKey struct {
+	// The expected result of the `Key` call.
+	Expected []byte
+}
+
+var _ IteratorAction = (*IteratorKey)(nil)
+
+// Key returns a [IteratorKey] iterator action that executes a single `Key` call
+// on an [Iterator] and requires that the returned result equals the given expected key.
+func Key(expected []byte) *IteratorKey {
+	return &IteratorKey{
+		Expected: expected,
+	}
+}
+
+func (a *IteratorKey) Execute(s *state.State, iterator corekv.Iterator) {
+	actual := iterator.Key()
+
+	require.Equal(s.T, a.Expected, actual)
+}
+
+// MovePrev executes a single `Prev` call on a [corekv.BidirectionalIterator].
+type MovePrev struct {
+	ExpectValue bool
+}
+
+var _ IteratorAction = (*MovePrev)(nil)
+
+// Prev returns a [MovePrev] iterator action that executes a single `Prev` call
+// on a [corekv.BidirectionalIterator].
+func Prev(expectValue bool) *MovePrev {
+	return &MovePrev{
+		ExpectValue: expectValue,
+	}
+}
+
+func (a *MovePrev) Execute(s *state.State, iterator corekv.Iterator) {
+	bIterator, ok := iterator.(corekv.BidirectionalIterator)
+	require.True(s.T, ok, "iterator does not support moving backwards")
+
+	hasValue, err := bIterator.Prev()
+	require.NoError(s.T, err)
+
+	require.Equal(s.T, a.ExpectValue, hasValue)
+}
+
+// IteratorPeek executes a single `Peek` call on a [corekv.BidirectionalIterator] and
+// requires that the returned result matches the given `Expected` key.
+type IteratorPeek struct {
+	// The expected key returned by the `Peek` call.
+	Expected []byte
+
+	ExpectValue bool
+}
+
+var _ IteratorAction = (*IteratorPeek)(nil)
+
+// Peek returns a [IteratorPeek] iterator action that executes a single `Peek` call
+// on a [corekv.BidirectionalIterator] and requires that the returned result equals
+// the given expected key.
+func Peek(expected []byte, expectValue bool) *IteratorPeek {
+	return &IteratorPeek{
+		Expected:    expected,
+		ExpectValue: expectValue,
+	}
+}
+
+func (a *IteratorPeek) Execute(s *state.State, iterator corekv.Iterator) {
+	bIterator, ok := iterator.(corekv.BidirectionalIterator)
+	require.True(s.T, ok, "iterator does not support peeking")
+
+	actual, hasValue, err := bIterator.Peek()
+	require.NoError(s.T, err)
+
+	require.Equal(s.T, a.ExpectValue, hasValue)
+	require.Equal(s.T, a.Expected, actual)
+}
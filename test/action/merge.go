@@ -0,0 +1,83 @@
+package action
+
+import (
+	"github.com/sourcenetwork/corekv"
+	"github.com/sourcenetwork/corekv/merge"
+	"github.com/sourcenetwork/corekv/namespace"
+	"github.com/sourcenetwork/corekv/test/state"
+	"github.com/stretchr/testify/require"
+)
+
+// MergedIterate action will iterate through the items of the given namespaces of the
+// active store, merged using [merge.Iterate], when executed.
+type MergedIterate struct {
+	corekv.IterOptions
+
+	// The namespaces of the active store to merge, in source order.
+	Namespaces [][]byte
+
+	// The tie breaker to merge items with using, if nil the default will be used.
+	TieBreaker merge.TieBreaker
+
+	// The items expected to be yielded when iterating using the given options.
+	//
+	// Matching element order is required.
+	Expected []KeyValue
+}
+
+var _ Action = (*MergedIterate)(nil)
+
+func (a *MergedIterate) Execute(s *state.State) {
+	iterator := newMergedIterator(s, a.IterOptions, a.TieBreaker, a.Namespaces)
+
+	entries, err := iterate(iterator)
+	require.NoError(s.T, err)
+
+	err = iterator.Close(s.Ctx)
+	require.NoError(s.T, err)
+
+	require.Equal(s.T, a.Expected, entries)
+}
+
+// MergedIterator action will create an iterator over the items of the given namespaces
+// of the active store, merged using [merge.Iterate], execute the given child actions on
+// it, then release the iterator when executed.
+type MergedIterator struct {
+	corekv.IterOptions
+
+	// The namespaces of the active store to merge, in source order.
+	Namespaces [][]byte
+
+	// The tie breaker to merge items with using, if nil the default will be used.
+	TieBreaker merge.TieBreaker
+
+	// The actions to execute on the created iterator.
+	ChildActions []IteratorAction
+}
+
+var _ Action = (*MergedIterator)(nil)
+
+func (a *MergedIterator) Execute(s *state.State) {
+	iterator := newMergedIterator(s, a.IterOptions, a.TieBreaker, a.Namespaces)
+
+	for _, action := range a.ChildActions {
+		action.Execute(s, iterator)
+	}
+
+	err := iterator.Close(s.Ctx)
+	require.NoError(s.T, err)
+}
+
+func newMergedIterator(
+	s *state.State,
+	opts corekv.IterOptions,
+	tieBreaker merge.TieBreaker,
+	namespaces [][]byte,
+) corekv.Iterator {
+	readers := make([]corekv.Reader, len(namespaces))
+	for i, ns := range namespaces {
+		readers[i] = namespace.Wrap(s.Store, ns)
+	}
+
+	return merge.Iterate(s.Ctx, opts, tieBreaker, readers...)
+}
@@ -0,0 +1,90 @@
+package action
+
+import (
+	"strings"
+
+	"github.com/sourcenetwork/corekv/metrics"
+	"github.com/sourcenetwork/corekv/test/state"
+	"github.com/stretchr/testify/require"
+)
+
+// MetricsStore action will wrap the current store (replacing it) in a store that records
+// measurements of its use into a new [*metrics.MemoryRecorder] when executed.
+type MetricsStore struct{}
+
+var _ Action = (*MetricsStore)(nil)
+
+// Metrics returns a new [*MetricsStore] action that will wrap the current store in an
+// instrumented store when executed.
+func Metrics() *MetricsStore {
+	return &MetricsStore{}
+}
+
+func (a *MetricsStore) Execute(s *state.State) {
+	s.Store = metrics.Wrap(s.Store, metrics.NewMemoryRecorder())
+}
+
+// OperationCount action will require that the current instrumented store has recorded the
+// expected number of operations of the given kind and error class when executed.
+type OperationCount struct {
+	Op       metrics.Operation
+	Class    metrics.ErrorClass
+	Expected uint64
+}
+
+var _ Action = (*OperationCount)(nil)
+
+// Operations returns a new [*OperationCount] action that will require that the current
+// instrumented store has recorded the given number of operations when executed.
+func Operations(op metrics.Operation, class metrics.ErrorClass, expected uint64) *OperationCount {
+	return &OperationCount{
+		Op:       op,
+		Class:    class,
+		Expected: expected,
+	}
+}
+
+func (a *OperationCount) Execute(s *state.State) {
+	recorder := memoryRecorder(s)
+
+	require.Equal(s.T, a.Expected, recorder.OperationCount(a.Op, a.Class))
+}
+
+// PrometheusContains action will require that the Prometheus output of the current
+// instrumented store contains each of the expected lines when executed.
+type PrometheusContains struct {
+	Expected []string
+}
+
+var _ Action = (*PrometheusContains)(nil)
+
+// Prometheus returns a new [*PrometheusContains] action that will require that the Prometheus
+// output of the current instrumented store contains each of the given lines when executed.
+func Prometheus(expected ...string) *PrometheusContains {
+	return &PrometheusContains{
+		Expected: expected,
+	}
+}
+
+func (a *PrometheusContains) Execute(s *state.State) {
+	recorder := memoryRecorder(s)
+
+	var output strings.Builder
+	err := recorder.WritePrometheus(&output)
+	require.NoError(s.T, err)
+
+	lines := strings.Split(output.String(), "\n")
+	for _, expected := range a.Expected {
+		require.Contains(s.T, lines, expected)
+	}
+}
+
+func memoryRecorder(s *state.State) *metrics.MemoryRecorder {
+	store, ok := s.Store.(metrics.Instrumented)
+	require.True(s.T, ok, "store is not instrumented")
+
+	recorder, ok := store.Recorder().(*metrics.MemoryRecorder)
+	require.True(s.T, ok, "recorder is not a memory recorder")
+
+	return recorder
+}
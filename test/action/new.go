@@ -1,6 +1,8 @@
 package action
 
 import (
+	"time"
+
 	badgerds "github.com/dgraph-io/badger/v4"
 	"github.com/sourcenetwork/corekv"
 	"github.com/sourcenetwork/corekv/badger"
@@ -31,7 +33,9 @@ func (a *NewStore) Execute(s *state.State) {
 		require.NoError(s.T, err)
 
 	case state.MemoryStoreType:
-		store = memory.NewDatastore(s.Ctx)
+		// The memory store's clock can be set, allowing expiry to be tested without waiting.
+		s.Clock = state.NewClock(time.Now())
+		store = memory.NewDatastore(s.Ctx, memory.WithClock(s.Clock.Now))
 	}
 
 	s.Rootstore = store
@@ -0,0 +1,96 @@
+package action
+
+import (
+	"github.com/sourcenetwork/corekv/memory"
+	"github.com/sourcenetwork/corekv/overlay"
+	"github.com/sourcenetwork/corekv/test/state"
+	"github.com/stretchr/testify/require"
+)
+
+// OverlayStore action will layer a new, in-memory, overlay over the current store (replacing
+// it) when executed.
+type OverlayStore struct{}
+
+var _ Action = (*OverlayStore)(nil)
+
+// Overlay returns a new [*OverlayStore] action that will layer a new, in-memory, overlay
+// over the current store when executed.
+func Overlay() *OverlayStore {
+	return &OverlayStore{}
+}
+
+func (a *OverlayStore) Execute(s *state.State) {
+	s.Store = overlay.Wrap(s.Store, memory.NewDatastore(s.Ctx))
+}
+
+// FlushOverlay action will flush the changes held in the current overlay store to its base
+// store when executed.
+type FlushOverlay struct {
+	ExpectedError string
+}
+
+var _ Action = (*FlushOverlay)(nil)
+
+// Flush returns a new [*FlushOverlay] action that will flush the changes held in the
+// current overlay store to its base store when executed.
+func Flush() *FlushOverlay {
+	return &FlushOverlay{}
+}
+
+func (a *FlushOverlay) Execute(s *state.State) {
+	store, ok := s.Store.(*overlay.Store)
+	require.True(s.T, ok, "store is not an overlay")
+
+	err := store.Flush(s.Ctx)
+	expectError(s, err, a.ExpectedError)
+}
+
+// DiscardOverlay action will discard the changes held in the current overlay store when
+// executed.
+type DiscardOverlay struct{}
+
+var _ Action = (*DiscardOverlay)(nil)
+
+// DiscardChanges returns a new [*DiscardOverlay] action that will discard the changes held
+// in the current overlay store when executed.
+func DiscardChanges() *DiscardOverlay {
+	return &DiscardOverlay{}
+}
+
+func (a *DiscardOverlay) Execute(s *state.State) {
+	store, ok := s.Store.(*overlay.Store)
+	require.True(s.T, ok, "store is not an overlay")
+
+	err := store.Discard(s.Ctx)
+	require.NoError(s.T, err)
+}
+
+// BaseAction wraps an action within the context of the base store of the current
+// overlay store.
+type BaseAction struct {
+	Action Action
+}
+
+var _ Action = (*BaseAction)(nil)
+
+// WithBase wraps an action within the context of the base store of the current
+// overlay store.
+//
+// The action will be executed directly against the base store, bypassing the overlay.
+func WithBase(action Action) *BaseAction {
+	return &BaseAction{
+		Action: action,
+	}
+}
+
+func (a *BaseAction) Execute(s *state.State) {
+	store, ok := s.Store.(*overlay.Store)
+	require.True(s.T, ok, "store is not an overlay")
+
+	defer func() {
+		s.Store = store
+	}()
+
+	s.Store = store.Base()
+	a.Action.Execute(s)
+}
@@ -0,0 +1,77 @@
+package action
+
+import (
+	"github.com/sourcenetwork/corekv"
+	"github.com/sourcenetwork/corekv/test/state"
+	"github.com/stretchr/testify/require"
+)
+
+// RunInTxnAction will run the given function via [corekv.RunInTxn] against the active store
+// when executed.
+//
+// The active store must be a [corekv.TxnStore].
+type RunInTxnAction struct {
+	Options corekv.RetryOptions
+
+	// The function to run within each transaction.
+	//
+	// Attempt is the number of the attempt being made, starting at one.  The active store
+	// remains accessible via the given state, allowing functions to write outside of the
+	// transaction in order to provoke conflicts.
+	Fn func(s *state.State, txn corekv.Txn, attempt int) error
+
+	// The number of times the function is expected to have been run.
+	ExpectedAttempts int
+	ExpectedError    string
+}
+
+var _ Action = (*RunInTxnAction)(nil)
+
+// RunInTxn returns a new [*RunInTxnAction] action that will run the given function via
+// [corekv.RunInTxn] when executed, and require that it was run the given number of times.
+func RunInTxn(
+	opts corekv.RetryOptions,
+	expectedAttempts int,
+	fn func(s *state.State, txn corekv.Txn, attempt int) error,
+) *RunInTxnAction {
+	return &RunInTxnAction{
+		Options:          opts,
+		Fn:               fn,
+		ExpectedAttempts: expectedAttempts,
+	}
+}
+
+// RunInTxnE returns a new [*RunInTxnAction] action that will run the given function via
+// [corekv.RunInTxn] when executed, and require that it was run the given number of times
+// and that the returned error contains the given string.
+func RunInTxnE(
+	opts corekv.RetryOptions,
+	expectedAttempts int,
+	fn func(s *state.State, txn corekv.Txn, attempt int) error,
+	expectedErr string,
+) *RunInTxnAction {
+	return &RunInTxnAction{
+		Options:          opts,
+		Fn:               fn,
+		ExpectedAttempts: expectedAttempts,
+		ExpectedError:    expectedErr,
+	}
+}
+
+func (a *RunInTxnAction) Execute(s *state.State) {
+	store, ok := s.Store.(corekv.TxnStore)
+	require.True(s.T, ok, "store does not support transactions")
+
+	var attempts int
+	err := corekv.RunInTxn(
+		s.Ctx,
+		store,
+		func(txn corekv.Txn) error {
+			attempts++
+			return a.Fn(s, txn, attempts)
+		},
+		a.Options,
+	)
+	expectError(s, err, a.ExpectedError)
+	require.Equal(s.T, a.ExpectedAttempts, attempts)
+}
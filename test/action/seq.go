@@ -0,0 +1,74 @@
+//go:build go1.23
+
+package action
+
+import (
+	"github.com/sourcenetwork/corekv"
+	"github.com/sourcenetwork/corekv/test/state"
+	"github.com/stretchr/testify/require"
+)
+
+// IterateSeq action will range over the active store using [corekv.Scan] and the
+// given options when executed.
+type IterateSeq struct {
+	corekv.IterOptions
+
+	// If set, ranging will stop after this many items have been yielded.
+	Take int
+
+	// The items expected to be yielded when iterating using the given options.
+	//
+	// Matching element order is required.
+	Expected []KeyValue
+}
+
+var _ Action = (*IterateSeq)(nil)
+
+func (a *IterateSeq) Execute(s *state.State) {
+	seq, seqErr := corekv.Scan(s.Ctx, s.Store, a.IterOptions)
+
+	entries := make([]KeyValue, 0)
+	for key, value := range seq {
+		entries = append(entries, KeyValue{
+			Key:   key,
+			Value: value,
+		})
+		if a.Take > 0 && len(entries) == a.Take {
+			break
+		}
+	}
+	require.NoError(s.T, seqErr())
+
+	require.Equal(s.T, a.Expected, entries)
+}
+
+// IterateKeysSeq action will range over the keys in the active store using [corekv.ScanKeys]
+// and the given options when executed.
+type IterateKeysSeq struct {
+	corekv.IterOptions
+
+	// If set, ranging will stop after this many keys have been yielded.
+	Take int
+
+	// The keys expected to be yielded when iterating using the given options.
+	//
+	// Matching element order is required.
+	Expected [][]byte
+}
+
+var _ Action = (*IterateKeysSeq)(nil)
+
+func (a *IterateKeysSeq) Execute(s *state.State) {
+	seq, seqErr := corekv.ScanKeys(s.Ctx, s.Store, a.IterOptions)
+
+	keys := make([][]byte, 0)
+	for key := range seq {
+		keys = append(keys, key)
+		if a.Take > 0 && len(keys) == a.Take {
+			break
+		}
+	}
+	require.NoError(s.T, seqErr())
+
+	require.Equal(s.T, a.Expected, keys)
+}
@@ -0,0 +1,67 @@
+package action
+
+import (
+	"github.com/sourcenetwork/corekv/test/state"
+	"github.com/sourcenetwork/corekv/tracing"
+	"github.com/stretchr/testify/require"
+)
+
+// TraceStore action will wrap the current store (replacing it) in a store that creates spans
+// for its operations using a new [*tracing.MemoryTracer] when executed.
+type TraceStore struct {
+	KeyPrefixLength int
+}
+
+var _ Action = (*TraceStore)(nil)
+
+// Trace returns a new [*TraceStore] action that will wrap the current store in a traced
+// store, recording up to the given number of leading key bytes, when executed.
+func Trace(keyPrefixLength int) *TraceStore {
+	return &TraceStore{
+		KeyPrefixLength: keyPrefixLength,
+	}
+}
+
+func (a *TraceStore) Execute(s *state.State) {
+	s.Store = tracing.Wrap(s.Store, tracing.NewMemoryTracer(), a.KeyPrefixLength)
+}
+
+// TraceSpans action will require that the spans created by the current traced store match
+// the expected spans when executed.
+type TraceSpans struct {
+	// The spans expected to have been created.
+	//
+	// Matching element order is required.  The recorded errors are not compared, only
+	// whether or not any were recorded, using a nil or non-nil Errors value.
+	Expected []tracing.RecordedSpan
+}
+
+var _ Action = (*TraceSpans)(nil)
+
+// Spans returns a new [*TraceSpans] action that will require that the spans created by the
+// current traced store match the given spans when executed.
+func Spans(expected ...tracing.RecordedSpan) *TraceSpans {
+	return &TraceSpans{
+		Expected: expected,
+	}
+}
+
+func (a *TraceSpans) Execute(s *state.State) {
+	store, ok := s.Store.(tracing.Traced)
+	require.True(s.T, ok, "store is not traced")
+
+	tracer, ok := store.Tracer().(*tracing.MemoryTracer)
+	require.True(s.T, ok, "tracer is not a memory tracer")
+
+	spans := tracer.Spans()
+	require.Len(s.T, spans, len(a.Expected))
+
+	for i, expected := range a.Expected {
+		actual := spans[i]
+		require.Equal(s.T, expected.Name, actual.Name)
+		require.Equal(s.T, expected.Parent, actual.Parent)
+		require.Equal(s.T, expected.Attributes, actual.Attributes)
+		require.Equal(s.T, expected.Ended, actual.Ended)
+		require.Equal(s.T, expected.Errors == nil, actual.Errors == nil)
+	}
+}
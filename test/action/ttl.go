@@ -0,0 +1,64 @@
+package action
+
+import (
+	"time"
+
+	"github.com/sourcenetwork/corekv"
+	"github.com/sourcenetwork/corekv/test/state"
+	"github.com/stretchr/testify/require"
+)
+
+// SetValueWithTTL action will set the given key to the given value, with the given time-to-live,
+// when executed.
+type SetValueWithTTL struct {
+	Key           []byte
+	Value         []byte
+	TTL           time.Duration
+	ExpectedError string
+}
+
+var _ Action = (*SetValueWithTTL)(nil)
+
+// SetWithTTL returns a new [*SetValueWithTTL] action that will set the given key to the given value,
+// with the given time-to-live, when executed.
+func SetWithTTL(key []byte, value []byte, ttl time.Duration) *SetValueWithTTL {
+	return &SetValueWithTTL{
+		Key:   key,
+		Value: value,
+		TTL:   ttl,
+	}
+}
+
+func (a *SetValueWithTTL) Execute(s *state.State) {
+	store, ok := s.Store.(corekv.TTLWriter)
+	require.True(s.T, ok, "store does not support expiring items")
+
+	err := store.SetWithTTL(s.Ctx, a.Key, a.Value, a.TTL)
+	expectError(s, err, a.ExpectedError)
+}
+
+// Wait action will let the given duration pass for the root store when executed.
+//
+// If the root store's clock can be set it will be advanced by the duration, otherwise the
+// action will block for the duration.
+type Wait struct {
+	Duration time.Duration
+}
+
+var _ Action = (*Wait)(nil)
+
+// Sleep returns a new [*Wait] action that will let the given duration pass for the root
+// store when executed.
+func Sleep(duration time.Duration) *Wait {
+	return &Wait{
+		Duration: duration,
+	}
+}
+
+func (a *Wait) Execute(s *state.State) {
+	if s.Clock != nil {
+		s.Clock.Advance(a.Duration)
+		return
+	}
+	time.Sleep(a.Duration)
+}
@@ -0,0 +1,140 @@
+package action
+
+import (
+	"context"
+	"time"
+
+	"github.com/sourcenetwork/corekv"
+	"github.com/sourcenetwork/corekv/test/state"
+	"github.com/stretchr/testify/require"
+)
+
+// CreateTxn action will create a new transaction from the active store when executed.
+//
+// The active store must be a [corekv.TxnStore].  Created transactions are referenced by
+// their index, in order of creation, starting at zero.
+type CreateTxn struct {
+	ReadOnly bool
+}
+
+var _ Action = (*CreateTxn)(nil)
+
+// NewTxn returns a new [*CreateTxn] action that will create a new transaction from the
+// active store when executed.
+func NewTxn(readOnly bool) *CreateTxn {
+	return &CreateTxn{
+		ReadOnly: readOnly,
+	}
+}
+
+func (a *CreateTxn) Execute(s *state.State) {
+	store, ok := s.Store.(corekv.TxnStore)
+	require.True(s.T, ok, "store does not support transactions")
+
+	s.Txns = append(s.Txns, store.NewTxn(a.ReadOnly))
+}
+
+// TxnAction will execute the given child action against the transaction at the given
+// index, instead of the active store, when executed.
+type TxnAction struct {
+	// The index of the transaction to execute the child action against.
+	TxnID int
+
+	// The action to execute.
+	//
+	// The action must only read from and/or write to the active store.
+	Action Action
+}
+
+var _ Action = (*TxnAction)(nil)
+
+// WithTxn returns a new [*TxnAction] action that will execute the given child action against
+// the transaction at the given index when executed.
+func WithTxn(txnID int, action Action) *TxnAction {
+	return &TxnAction{
+		TxnID:  txnID,
+		Action: action,
+	}
+}
+
+func (a *TxnAction) Execute(s *state.State) {
+	store := s.Store
+	defer func() {
+		s.Store = store
+	}()
+
+	s.Store = &txnStore{s.Txns[a.TxnID]}
+	a.Action.Execute(s)
+}
+
+// txnStore allows a [corekv.Txn] to temporarily replace the active store whilst executing
+// [TxnAction]s.
+type txnStore struct {
+	corekv.Txn
+}
+
+var _ corekv.Store = (*txnStore)(nil)
+
+func (t *txnStore) Close() error {
+	return nil
+}
+
+// SetWithTTL allows [SetWithTTL] actions to be executed within transactions that implement
+// [corekv.TTLWriter].
+func (t *txnStore) SetWithTTL(ctx context.Context, key, value []byte, ttl time.Duration) error {
+	txn, ok := t.Txn.(corekv.TTLWriter)
+	if !ok {
+		return corekv.ErrNotSupported
+	}
+	return txn.SetWithTTL(ctx, key, value, ttl)
+}
+
+// CommitTxn action will commit the transaction at the given index when executed.
+type CommitTxn struct {
+	TxnID         int
+	ExpectedError string
+}
+
+var _ Action = (*CommitTxn)(nil)
+
+// Commit returns a new [*CommitTxn] action that will commit the transaction at the given
+// index when executed.
+func Commit(txnID int) *CommitTxn {
+	return &CommitTxn{
+		TxnID: txnID,
+	}
+}
+
+// CommitE returns a new [*CommitTxn] action that will commit the transaction at the given
+// index when executed and require that the returned error contains the given string.
+func CommitE(txnID int, expectedErr string) *CommitTxn {
+	return &CommitTxn{
+		TxnID:         txnID,
+		ExpectedError: expectedErr,
+	}
+}
+
+func (a *CommitTxn) Execute(s *state.State) {
+	err := s.Txns[a.TxnID].Commit(s.Ctx)
+	expectError(s, err, a.ExpectedError)
+}
+
+// DiscardTxn action will discard the transaction at the given index when executed.
+type DiscardTxn struct {
+	TxnID int
+}
+
+var _ Action = (*DiscardTxn)(nil)
+
+// Discard returns a new [*DiscardTxn] action that will discard the transaction at the given
+// index when executed.
+func Discard(txnID int) *DiscardTxn {
+	return &DiscardTxn{
+		TxnID: txnID,
+	}
+}
+
+func (a *DiscardTxn) Execute(s *state.State) {
+	err := s.Txns[a.TxnID].Discard(s.Ctx)
+	require.NoError(s.T, err)
+}
@@ -0,0 +1,114 @@
+package action
+
+import (
+	"time"
+
+	"github.com/sourcenetwork/corekv"
+	"github.com/sourcenetwork/corekv/test/state"
+	"github.com/stretchr/testify/require"
+)
+
+// eventTimeout is the maximum duration that [ExpectEvents] actions will wait for each
+// event to be yielded.
+const eventTimeout = 5 * time.Second
+
+// WatchPrefix action will watch the active store for changes to items with the given
+// prefix when executed.
+//
+// The active store must be a [corekv.Watchable].  Created watches are referenced by
+// their index, in order of creation, starting at zero.
+type WatchPrefix struct {
+	Prefix []byte
+}
+
+var _ Action = (*WatchPrefix)(nil)
+
+// Watch returns a new [*WatchPrefix] action that will watch the active store for changes
+// to items with the given prefix when executed.
+func Watch(prefix []byte) *WatchPrefix {
+	return &WatchPrefix{
+		Prefix: prefix,
+	}
+}
+
+func (a *WatchPrefix) Execute(s *state.State) {
+	store, ok := s.Store.(corekv.Watchable)
+	require.True(s.T, ok, "store does not support watching")
+
+	events, err := store.Watch(s.Ctx, a.Prefix)
+	require.NoError(s.T, err)
+
+	s.Watches = append(s.Watches, events)
+}
+
+// ExpectEvents action will receive events from the watch at the given index when executed,
+// and require that they match the given expected events.
+type ExpectEvents struct {
+	WatchID int
+
+	// The events expected to be yielded by the watch.
+	//
+	// Matching element order is required.
+	Expected []corekv.Event
+}
+
+var _ Action = (*ExpectEvents)(nil)
+
+// Events returns a new [*ExpectEvents] action that will receive events from the watch at
+// the given index when executed, and require that they match the given expected events.
+func Events(watchID int, expected ...corekv.Event) *ExpectEvents {
+	return &ExpectEvents{
+		WatchID:  watchID,
+		Expected: expected,
+	}
+}
+
+func (a *ExpectEvents) Execute(s *state.State) {
+	events := make([]corekv.Event, 0, len(a.Expected))
+	for range a.Expected {
+		select {
+		case event, ok := <-s.Watches[a.WatchID]:
+			require.True(s.T, ok, "watch closed")
+			events = append(events, event)
+
+		case <-time.After(eventTimeout):
+			require.Fail(s.T, "timeout waiting for event")
+		}
+	}
+
+	require.Equal(s.T, a.Expected, events)
+}
+
+// ExpectOverflow action will receive events from the watch at the given index until it is
+// closed when executed, and require that the final event reports that the watch overflowed.
+type ExpectOverflow struct {
+	WatchID int
+}
+
+var _ Action = (*ExpectOverflow)(nil)
+
+// Overflowed returns a new [*ExpectOverflow] action that will receive events from the watch
+// at the given index until it is closed when executed, and require that the final event
+// reports that the watch overflowed.
+func Overflowed(watchID int) *ExpectOverflow {
+	return &ExpectOverflow{
+		WatchID: watchID,
+	}
+}
+
+func (a *ExpectOverflow) Execute(s *state.State) {
+	var last corekv.Event
+	for {
+		select {
+		case event, ok := <-s.Watches[a.WatchID]:
+			if !ok {
+				require.ErrorIs(s.T, last.Err, corekv.ErrWatchOverflow)
+				return
+			}
+			last = event
+
+		case <-time.After(eventTimeout):
+			require.Fail(s.T, "timeout waiting for event")
+		}
+	}
+}
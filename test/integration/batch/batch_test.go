@@ -0,0 +1,151 @@
+package batch
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/sourcenetwork/corekv"
+	"github.com/sourcenetwork/corekv/test/action"
+	"github.com/sourcenetwork/corekv/test/integration"
+	"github.com/sourcenetwork/corekv/test/state"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBatch(t *testing.T) {
+	test := &integration.Test{
+		Actions: []action.Action{
+			action.NewBatch(),
+			action.BatchSet(0, []byte("k1"), []byte("v1")),
+			action.BatchSet(0, []byte("k2"), []byte("v2")),
+			action.GetE([]byte("k1"), corekv.ErrNotFound.Error()),
+			action.BatchCommit(0),
+			action.Get([]byte("k1"), []byte("v1")),
+			action.Get([]byte("k2"), []byte("v2")),
+		},
+	}
+
+	test.Execute(t)
+}
+
+func TestBatch_Delete(t *testing.T) {
+	test := &integration.Test{
+		Actions: []action.Action{
+			action.Set([]byte("k1"), []byte("v1")),
+			action.Set([]byte("k2"), []byte("v2")),
+			action.NewBatch(),
+			action.BatchDelete(0, []byte("k1")),
+			action.BatchSet(0, []byte("k3"), []byte("v3")),
+			action.Has([]byte("k1"), true),
+			action.BatchCommit(0),
+			action.Has([]byte("k1"), false),
+			&action.Iterate{
+				Expected: []action.KeyValue{
+					{Key: []byte("k2"), Value: []byte("v2")},
+					{Key: []byte("k3"), Value: []byte("v3")},
+				},
+			},
+		},
+	}
+
+	test.Execute(t)
+}
+
+func TestBatch_SetThenDelete(t *testing.T) {
+	test := &integration.Test{
+		Actions: []action.Action{
+			action.NewBatch(),
+			action.BatchSet(0, []byte("k1"), []byte("v1")),
+			action.BatchDelete(0, []byte("k1")),
+			action.BatchCommit(0),
+			action.Has([]byte("k1"), false),
+		},
+	}
+
+	test.Execute(t)
+}
+
+func TestBatch_Discard(t *testing.T) {
+	test := &integration.Test{
+		Actions: []action.Action{
+			action.NewBatch(),
+			action.BatchSet(0, []byte("k1"), []byte("v1")),
+			action.BatchDiscard(0),
+			action.Has([]byte("k1"), false),
+		},
+	}
+
+	test.Execute(t)
+}
+
+func TestBatch_EmptyKey_Errors(t *testing.T) {
+	test := &integration.Test{
+		Actions: []action.Action{
+			action.NewBatch(),
+			action.BatchSetE(0, []byte{}, []byte("v1"), corekv.ErrEmptyKey.Error()),
+			action.BatchDiscard(0),
+		},
+	}
+
+	test.Execute(t)
+}
+
+func TestBatch_BadgerStoreTooBig_Errors(t *testing.T) {
+	test := &integration.Test{
+		SupportedStoreTypes: []state.StoreType{
+			state.BadgerStoreType,
+		},
+		Actions: []action.Action{
+			action.NewBatch(),
+			&fillBatch{
+				BatchID:       0,
+				ValueSize:     100 * 1024,
+				ExpectedError: corekv.ErrTxnTooBig,
+			},
+			action.BatchDiscard(0),
+			// None of the operations made before the batch became too big may be applied.
+			action.Has([]byte("k0"), false),
+		},
+	}
+
+	test.Execute(t)
+}
+
+func TestBatch_BadgerStoreTooBig_CommitsCollected(t *testing.T) {
+	test := &integration.Test{
+		SupportedStoreTypes: []state.StoreType{
+			state.BadgerStoreType,
+		},
+		Actions: []action.Action{
+			action.NewBatch(),
+			&fillBatch{
+				BatchID:       0,
+				ValueSize:     100 * 1024,
+				ExpectedError: corekv.ErrTxnTooBig,
+			},
+			// The operations collected before the batch became too big may still be committed.
+			action.BatchCommit(0),
+			action.Has([]byte("k0"), true),
+		},
+	}
+
+	test.Execute(t)
+}
+
+// fillBatch sets values in the batch at the given index until an error is returned, and
+// requires that the error is the given error.
+type fillBatch struct {
+	BatchID       int
+	ValueSize     int
+	ExpectedError error
+}
+
+func (a *fillBatch) Execute(s *state.State) {
+	value := make([]byte, a.ValueSize)
+	for i := 0; ; i++ {
+		err := s.Batches[a.BatchID].Set(s.Ctx, []byte(fmt.Sprintf("k%v", i)), value)
+		if err != nil {
+			require.ErrorIs(s.T, err, a.ExpectedError)
+			return
+		}
+	}
+}
@@ -0,0 +1,306 @@
+package cache
+
+import (
+	"testing"
+	"time"
+
+	"github.com/sourcenetwork/corekv"
+	"github.com/sourcenetwork/corekv/cache"
+	"github.com/sourcenetwork/corekv/test/action"
+	"github.com/sourcenetwork/corekv/test/integration"
+)
+
+func TestCache_Get(t *testing.T) {
+	test := &integration.Test{
+		Actions: []action.Action{
+			action.Set([]byte("k1"), []byte("v1")),
+			action.Cache(1024),
+			action.Get([]byte("k1"), []byte("v1")),
+			action.Get([]byte("k1"), []byte("v1")),
+			action.Has([]byte("k1"), true),
+			action.Stats(cache.Stats{
+				Hits:    2,
+				Misses:  1,
+				Entries: 1,
+				Cost:    68,
+			}),
+		},
+	}
+
+	test.Execute(t)
+}
+
+func TestCache_NegativeCaching(t *testing.T) {
+	test := &integration.Test{
+		Actions: []action.Action{
+			action.Cache(1024),
+			action.GetE([]byte("k1"), corekv.ErrNotFound.Error()),
+			action.GetE([]byte("k1"), corekv.ErrNotFound.Error()),
+			action.Has([]byte("k1"), false),
+			action.Stats(cache.Stats{
+				Hits:    2,
+				Misses:  1,
+				Entries: 1,
+				Cost:    66,
+			}),
+		},
+	}
+
+	test.Execute(t)
+}
+
+func TestCache_HasThenGet(t *testing.T) {
+	test := &integration.Test{
+		Actions: []action.Action{
+			action.Set([]byte("k1"), []byte("v1")),
+			action.Cache(1024),
+			action.Has([]byte("k1"), true),
+			// Only the existence of `k1` is known, so the value must be read from the store
+			action.Get([]byte("k1"), []byte("v1")),
+			action.Get([]byte("k1"), []byte("v1")),
+			action.Stats(cache.Stats{
+				Hits:    1,
+				Misses:  2,
+				Entries: 1,
+				Cost:    68,
+			}),
+		},
+	}
+
+	test.Execute(t)
+}
+
+func TestCache_SetInvalidates(t *testing.T) {
+	test := &integration.Test{
+		Actions: []action.Action{
+			action.Cache(1024),
+			action.GetE([]byte("k1"), corekv.ErrNotFound.Error()),
+			action.Set([]byte("k1"), []byte("v1")),
+			action.Get([]byte("k1"), []byte("v1")),
+			action.Set([]byte("k1"), []byte("v1.1")),
+			action.Get([]byte("k1"), []byte("v1.1")),
+			action.Delete([]byte("k1")),
+			action.GetE([]byte("k1"), corekv.ErrNotFound.Error()),
+			action.Has([]byte("k1"), false),
+		},
+	}
+
+	test.Execute(t)
+}
+
+func TestCache_TxnCommitInvalidates(t *testing.T) {
+	test := &integration.Test{
+		Actions: []action.Action{
+			action.Set([]byte("k1"), []byte("v1")),
+			action.Cache(1024),
+			action.Get([]byte("k1"), []byte("v1")),
+			action.Has([]byte("k2"), false),
+			action.NewTxn(false),
+			action.WithTxn(0, action.Set([]byte("k1"), []byte("v1.1"))),
+			action.WithTxn(0, action.Set([]byte("k2"), []byte("v2"))),
+			// The transaction has not yet been committed, so the cached values remain valid
+			action.Get([]byte("k1"), []byte("v1")),
+			action.Has([]byte("k2"), false),
+			action.Commit(0),
+			action.Get([]byte("k1"), []byte("v1.1")),
+			action.Has([]byte("k2"), true),
+		},
+	}
+
+	test.Execute(t)
+}
+
+func TestCache_TxnDiscardDoesNotInvalidate(t *testing.T) {
+	test := &integration.Test{
+		Actions: []action.Action{
+			action.Set([]byte("k1"), []byte("v1")),
+			action.Cache(1024),
+			action.Get([]byte("k1"), []byte("v1")),
+			action.NewTxn(false),
+			action.WithTxn(0, action.Set([]byte("k1"), []byte("v1.1"))),
+			action.Discard(0),
+			action.Get([]byte("k1"), []byte("v1")),
+			action.Stats(cache.Stats{
+				Hits:    1,
+				Misses:  1,
+				Entries: 1,
+				Cost:    68,
+			}),
+		},
+	}
+
+	test.Execute(t)
+}
+
+func TestCache_EvictsLeastRecentlyUsed(t *testing.T) {
+	test := &integration.Test{
+		Actions: []action.Action{
+			action.Set([]byte("k1"), []byte("v1")),
+			action.Set([]byte("k2"), []byte("v2")),
+			action.Set([]byte("k3"), []byte("v3")),
+			// Each entry costs 68, so only two may be held at once
+			action.Cache(140),
+			action.Get([]byte("k1"), []byte("v1")),
+			action.Get([]byte("k2"), []byte("v2")),
+			action.Get([]byte("k1"), []byte("v1")),
+			// `k2` is now the least recently used, and will be evicted
+			action.Get([]byte("k3"), []byte("v3")),
+			action.Get([]byte("k1"), []byte("v1")),
+			action.Get([]byte("k2"), []byte("v2")),
+			action.Stats(cache.Stats{
+				Hits:      2,
+				Misses:    4,
+				Evictions: 2,
+				Entries:   2,
+				Cost:      136,
+			}),
+		},
+	}
+
+	test.Execute(t)
+}
+
+func TestCache_SetWithTTLExpires(t *testing.T) {
+	test := &integration.Test{
+		Actions: []action.Action{
+			action.Cache(1024),
+			// Badger truncates expiry times to the second, so a time-to-live of at least two
+			// seconds is needed for `k1` to be reliably readable until it is next waited on.
+			action.SetWithTTL([]byte("k1"), []byte("v1"), 2*time.Second),
+			action.Get([]byte("k1"), []byte("v1")),
+			action.Get([]byte("k1"), []byte("v1")),
+			// Keys with a time-to-live must not be cached, else they would be served after expiry
+			action.Stats(cache.Stats{
+				Misses: 2,
+			}),
+			action.Sleep(2 * time.Second),
+			action.GetE([]byte("k1"), corekv.ErrNotFound.Error()),
+			action.GetE([]byte("k1"), corekv.ErrNotFound.Error()),
+			action.Stats(cache.Stats{
+				Hits:    1,
+				Misses:  3,
+				Entries: 1,
+				Cost:    66,
+			}),
+		},
+	}
+
+	test.Execute(t)
+}
+
+func TestCache_SetWithTTLOverwrittenBySet(t *testing.T) {
+	test := &integration.Test{
+		Actions: []action.Action{
+			action.Cache(1024),
+			action.SetWithTTL([]byte("k1"), []byte("v1"), time.Hour),
+			action.Set([]byte("k1"), []byte("v1.1")),
+			action.Get([]byte("k1"), []byte("v1.1")),
+			action.Get([]byte("k1"), []byte("v1.1")),
+			action.Stats(cache.Stats{
+				Hits:    1,
+				Misses:  1,
+				Entries: 1,
+				Cost:    70,
+			}),
+		},
+	}
+
+	test.Execute(t)
+}
+
+func TestCache_BatchCommitInvalidates(t *testing.T) {
+	test := &integration.Test{
+		Actions: []action.Action{
+			action.Set([]byte("k1"), []byte("v1")),
+			action.Cache(1024),
+			action.Get([]byte("k1"), []byte("v1")),
+			action.Has([]byte("k2"), false),
+			action.NewBatch(),
+			action.BatchSet(0, []byte("k1"), []byte("v1.1")),
+			action.BatchSet(0, []byte("k2"), []byte("v2")),
+			// The batch has not yet been committed, so the cached values remain valid
+			action.Get([]byte("k1"), []byte("v1")),
+			action.BatchCommit(0),
+			action.Get([]byte("k1"), []byte("v1.1")),
+			action.Has([]byte("k2"), true),
+		},
+	}
+
+	test.Execute(t)
+}
+
+func TestCache_DeleteRangeInvalidates(t *testing.T) {
+	test := &integration.Test{
+		Actions: []action.Action{
+			action.Set([]byte("k1"), []byte("v1")),
+			action.Set([]byte("k2"), []byte("v2")),
+			action.Set([]byte("k3"), []byte("v3")),
+			action.Cache(1024),
+			action.Get([]byte("k1"), []byte("v1")),
+			action.Get([]byte("k2"), []byte("v2")),
+			action.Get([]byte("k3"), []byte("v3")),
+			action.DeleteRange([]byte("k1"), []byte("k3")),
+			action.GetE([]byte("k1"), corekv.ErrNotFound.Error()),
+			action.GetE([]byte("k2"), corekv.ErrNotFound.Error()),
+			action.Get([]byte("k3"), []byte("v3")),
+			action.Stats(cache.Stats{
+				// Only `k3` remains cached from before the deletion
+				Hits:    1,
+				Misses:  5,
+				Entries: 3,
+				Cost:    200,
+			}),
+		},
+	}
+
+	test.Execute(t)
+}
+
+func TestCache_DeletePrefixInvalidates(t *testing.T) {
+	test := &integration.Test{
+		Actions: []action.Action{
+			action.Set([]byte("a1"), []byte("v1")),
+			action.Set([]byte("k1"), []byte("v1")),
+			action.Cache(1024),
+			action.Get([]byte("a1"), []byte("v1")),
+			action.Get([]byte("k1"), []byte("v1")),
+			action.DeletePrefix([]byte("k")),
+			action.Get([]byte("a1"), []byte("v1")),
+			action.GetE([]byte("k1"), corekv.ErrNotFound.Error()),
+		},
+	}
+
+	test.Execute(t)
+}
+
+func TestCache_CompareAndSwapInvalidates(t *testing.T) {
+	test := &integration.Test{
+		Actions: []action.Action{
+			action.Set([]byte("k1"), []byte("v1")),
+			action.Cache(1024),
+			action.Get([]byte("k1"), []byte("v1")),
+			action.CompareAndSwap([]byte("k1"), []byte("v1"), []byte("v1.1"), true),
+			action.Get([]byte("k1"), []byte("v1.1")),
+		},
+	}
+
+	test.Execute(t)
+}
+
+func TestCache_TxnSetWithTTLNotCached(t *testing.T) {
+	test := &integration.Test{
+		Actions: []action.Action{
+			action.Cache(1024),
+			action.NewTxn(false),
+			action.WithTxn(0, action.SetWithTTL([]byte("k1"), []byte("v1"), time.Hour)),
+			action.Commit(0),
+			action.Get([]byte("k1"), []byte("v1")),
+			action.Get([]byte("k1"), []byte("v1")),
+			action.Stats(cache.Stats{
+				Misses: 2,
+			}),
+		},
+	}
+
+	test.Execute(t)
+}
@@ -1,8 +1,10 @@
 package integration
 
 import (
+	"context"
 	"testing"
 
+	"github.com/sourcenetwork/corekv"
 	"github.com/sourcenetwork/corekv/test/action"
 )
 
@@ -16,3 +18,153 @@ func TestCancel(t *testing.T) {
 
 	test.Execute(t)
 }
+
+func TestCancel_MidIteration(t *testing.T) {
+	test := &Test{
+		Actions: []action.Action{
+			action.Set([]byte("k1"), []byte("v1")),
+			action.Set([]byte("k2"), []byte("v2")),
+			action.Set([]byte("k3"), []byte("v3")),
+			&action.CancelIteration{
+				CancelAfter: 2,
+				Expected: []action.KeyValue{
+					{Key: []byte("k1"), Value: []byte("v1")},
+					{Key: []byte("k2"), Value: []byte("v2")},
+				},
+			},
+			// The store must remain usable after the iteration was cancelled
+			action.Get([]byte("k3"), []byte("v3")),
+		},
+	}
+
+	test.Execute(t)
+}
+
+func TestCancel_MidIterationReverse(t *testing.T) {
+	test := &Test{
+		Actions: []action.Action{
+			action.Set([]byte("k1"), []byte("v1")),
+			action.Set([]byte("k2"), []byte("v2")),
+			action.Set([]byte("k3"), []byte("v3")),
+			&action.CancelIteration{
+				IterOptions: corekv.IterOptions{
+					Reverse: true,
+				},
+				CancelAfter: 1,
+				Expected: []action.KeyValue{
+					{Key: []byte("k3"), Value: []byte("v3")},
+				},
+			},
+		},
+	}
+
+	test.Execute(t)
+}
+
+func TestCancel_BeforeIteration(t *testing.T) {
+	test := &Test{
+		Actions: []action.Action{
+			action.Set([]byte("k1"), []byte("v1")),
+			&action.CancelIteration{
+				CancelAfter: 0,
+				Expected:    []action.KeyValue{},
+			},
+		},
+	}
+
+	test.Execute(t)
+}
+
+func TestCancel_MidIterationTxn(t *testing.T) {
+	test := &Test{
+		Actions: []action.Action{
+			action.Set([]byte("k1"), []byte("v1")),
+			action.NewTxn(false),
+			action.WithTxn(0, action.Set([]byte("k2"), []byte("v2"))),
+			action.WithTxn(0, &action.CancelIteration{
+				CancelAfter: 2,
+				Expected: []action.KeyValue{
+					{Key: []byte("k1"), Value: []byte("v1")},
+					{Key: []byte("k2"), Value: []byte("v2")},
+				},
+			}),
+			action.Commit(0),
+			action.Get([]byte("k2"), []byte("v2")),
+		},
+	}
+
+	test.Execute(t)
+}
+
+func TestCancel_Commit(t *testing.T) {
+	test := &Test{
+		Actions: []action.Action{
+			action.NewTxn(false),
+			action.WithTxn(0, action.Set([]byte("k1"), []byte("v1"))),
+			action.WithCancelledCtx(action.CommitE(0, context.Canceled.Error())),
+			action.GetE([]byte("k1"), corekv.ErrNotFound.Error()),
+		},
+	}
+
+	test.Execute(t)
+}
+
+func TestCancel_Set(t *testing.T) {
+	test := &Test{
+		Actions: []action.Action{
+			action.WithCancelledCtx(action.SetE([]byte("k1"), []byte("v1"), context.Canceled.Error())),
+			action.GetE([]byte("k1"), corekv.ErrNotFound.Error()),
+		},
+	}
+
+	test.Execute(t)
+}
+
+func TestCancel_SetIfAbsent(t *testing.T) {
+	test := &Test{
+		Actions: []action.Action{
+			action.WithCancelledCtx(action.SetIfAbsentE([]byte("k1"), []byte("v1"), context.Canceled.Error())),
+			action.GetE([]byte("k1"), corekv.ErrNotFound.Error()),
+		},
+	}
+
+	test.Execute(t)
+}
+
+func TestCancel_BatchCommit(t *testing.T) {
+	test := &Test{
+		Actions: []action.Action{
+			action.NewBatch(),
+			action.BatchSet(0, []byte("k1"), []byte("v1")),
+			action.WithCancelledCtx(action.BatchCommitE(0, context.Canceled.Error())),
+			action.GetE([]byte("k1"), corekv.ErrNotFound.Error()),
+		},
+	}
+
+	test.Execute(t)
+}
+
+func TestCancel_GetMany(t *testing.T) {
+	test := &Test{
+		Actions: []action.Action{
+			action.Set([]byte("k1"), []byte("v1")),
+			action.WithCancelledCtx(action.GetManyE([][]byte{[]byte("k1")}, context.Canceled.Error())),
+		},
+	}
+
+	test.Execute(t)
+}
+
+func TestCancel_DeleteRange(t *testing.T) {
+	test := &Test{
+		Actions: []action.Action{
+			action.Set([]byte("k1"), []byte("v1")),
+			action.Set([]byte("k2"), []byte("v2")),
+			action.WithCancelledCtx(action.DeleteRangeE([]byte("k1"), []byte("k3"), context.Canceled.Error())),
+			action.Get([]byte("k1"), []byte("v1")),
+			action.Get([]byte("k2"), []byte("v2")),
+		},
+	}
+
+	test.Execute(t)
+}
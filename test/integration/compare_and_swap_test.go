@@ -0,0 +1,56 @@
+package integration
+
+import (
+	"testing"
+
+	"github.com/sourcenetwork/corekv/test/action"
+)
+
+func TestCompareAndSwap(t *testing.T) {
+	test := &Test{
+		Actions: []action.Action{
+			action.Set([]byte("k1"), []byte("v1")),
+			action.CompareAndSwap([]byte("k1"), []byte("v1"), []byte("v2"), true),
+			action.Get([]byte("k1"), []byte("v2")),
+		},
+	}
+
+	test.Execute(t)
+}
+
+func TestCompareAndSwap_ValueMismatch(t *testing.T) {
+	test := &Test{
+		Actions: []action.Action{
+			action.Set([]byte("k1"), []byte("v1")),
+			action.CompareAndSwap([]byte("k1"), []byte("v0"), []byte("v2"), false),
+			action.Get([]byte("k1"), []byte("v1")),
+		},
+	}
+
+	test.Execute(t)
+}
+
+func TestCompareAndSwap_KeyDoesNotExist(t *testing.T) {
+	test := &Test{
+		Actions: []action.Action{
+			action.CompareAndSwap([]byte("k1"), nil, []byte("v2"), false),
+			action.Has([]byte("k1"), false),
+		},
+	}
+
+	test.Execute(t)
+}
+
+func TestCompareAndSwap_Sequential(t *testing.T) {
+	test := &Test{
+		Actions: []action.Action{
+			action.Set([]byte("counter"), []byte("1")),
+			action.CompareAndSwap([]byte("counter"), []byte("1"), []byte("2"), true),
+			action.CompareAndSwap([]byte("counter"), []byte("1"), []byte("2"), false),
+			action.CompareAndSwap([]byte("counter"), []byte("2"), []byte("3"), true),
+			action.Get([]byte("counter"), []byte("3")),
+		},
+	}
+
+	test.Execute(t)
+}
@@ -0,0 +1,41 @@
+package integration
+
+import (
+	"testing"
+
+	"github.com/sourcenetwork/corekv/test/action"
+)
+
+func TestDeleteIfEquals(t *testing.T) {
+	test := &Test{
+		Actions: []action.Action{
+			action.Set([]byte("k1"), []byte("v1")),
+			action.DeleteIfEquals([]byte("k1"), []byte("v1"), true),
+			action.Has([]byte("k1"), false),
+		},
+	}
+
+	test.Execute(t)
+}
+
+func TestDeleteIfEquals_ValueMismatch(t *testing.T) {
+	test := &Test{
+		Actions: []action.Action{
+			action.Set([]byte("k1"), []byte("v1")),
+			action.DeleteIfEquals([]byte("k1"), []byte("v2"), false),
+			action.Get([]byte("k1"), []byte("v1")),
+		},
+	}
+
+	test.Execute(t)
+}
+
+func TestDeleteIfEquals_KeyDoesNotExist(t *testing.T) {
+	test := &Test{
+		Actions: []action.Action{
+			action.DeleteIfEquals([]byte("k1"), nil, false),
+		},
+	}
+
+	test.Execute(t)
+}
@@ -0,0 +1,41 @@
+package integration
+
+import (
+	"testing"
+
+	"github.com/sourcenetwork/corekv"
+	"github.com/sourcenetwork/corekv/test/action"
+)
+
+func TestDeletePrefix(t *testing.T) {
+	test := &Test{
+		Actions: []action.Action{
+			action.Set([]byte("a1"), []byte("va1")),
+			action.Set([]byte("k"), []byte("v")),
+			action.Set([]byte("k1"), []byte("v1")),
+			action.Set([]byte("k2"), []byte("v2")),
+			action.Set([]byte("l1"), []byte("vl1")),
+			action.DeletePrefix([]byte("k")),
+			&action.Iterate{
+				Expected: []action.KeyValue{
+					{Key: []byte("a1"), Value: []byte("va1")},
+					{Key: []byte("l1"), Value: []byte("vl1")},
+				},
+			},
+		},
+	}
+
+	test.Execute(t)
+}
+
+func TestDeletePrefix_EmptyPrefix_Errors(t *testing.T) {
+	test := &Test{
+		Actions: []action.Action{
+			action.Set([]byte("k1"), []byte("v1")),
+			action.DeletePrefixE([]byte{}, corekv.ErrEmptyKey.Error()),
+			action.Has([]byte("k1"), true),
+		},
+	}
+
+	test.Execute(t)
+}
@@ -0,0 +1,92 @@
+package integration
+
+import (
+	"testing"
+
+	"github.com/sourcenetwork/corekv"
+	"github.com/sourcenetwork/corekv/test/action"
+)
+
+func TestDeleteRange(t *testing.T) {
+	test := &Test{
+		Actions: []action.Action{
+			action.Set([]byte("k1"), []byte("v1")),
+			action.Set([]byte("k2"), []byte("v2")),
+			action.Set([]byte("k3"), []byte("v3")),
+			action.Set([]byte("k4"), []byte("v4")),
+			action.DeleteRange([]byte("k2"), []byte("k4")),
+			&action.Iterate{
+				Expected: []action.KeyValue{
+					{Key: []byte("k1"), Value: []byte("v1")},
+					// `k2` and `k3` must have been deleted, `k4` is the exclusive end
+					{Key: []byte("k4"), Value: []byte("v4")},
+				},
+			},
+		},
+	}
+
+	test.Execute(t)
+}
+
+func TestDeleteRange_NilStart(t *testing.T) {
+	test := &Test{
+		Actions: []action.Action{
+			action.Set([]byte("k1"), []byte("v1")),
+			action.Set([]byte("k2"), []byte("v2")),
+			action.Set([]byte("k3"), []byte("v3")),
+			action.DeleteRange(nil, []byte("k3")),
+			&action.Iterate{
+				Expected: []action.KeyValue{
+					{Key: []byte("k3"), Value: []byte("v3")},
+				},
+			},
+		},
+	}
+
+	test.Execute(t)
+}
+
+func TestDeleteRange_NilEnd(t *testing.T) {
+	test := &Test{
+		Actions: []action.Action{
+			action.Set([]byte("k1"), []byte("v1")),
+			action.Set([]byte("k2"), []byte("v2")),
+			action.Set([]byte("k3"), []byte("v3")),
+			action.DeleteRange([]byte("k2"), nil),
+			&action.Iterate{
+				Expected: []action.KeyValue{
+					{Key: []byte("k1"), Value: []byte("v1")},
+				},
+			},
+		},
+	}
+
+	test.Execute(t)
+}
+
+func TestDeleteRange_Namespace(t *testing.T) {
+	test := &Test{
+		Actions: []action.Action{
+			action.Set([]byte("a1"), []byte("va1")),
+			action.Set([]byte("nsk1"), []byte("v1")),
+			action.Set([]byte("z1"), []byte("vz1")),
+			action.Namespace([]byte("ns")),
+			action.DeleteRange(nil, nil),
+			action.Has([]byte("k1"), false),
+		},
+	}
+
+	test.Execute(t)
+}
+
+func TestDeleteRange_StartAfterEnd_Errors(t *testing.T) {
+	test := &Test{
+		Actions: []action.Action{
+			action.Set([]byte("k1"), []byte("v1")),
+			action.DeleteRangeE([]byte("k2"), []byte("k1"), corekv.ErrInvalidRange.Error()),
+			action.Get([]byte("k1"), []byte("v1")),
+		},
+	}
+
+	test.Execute(t)
+}
@@ -0,0 +1,76 @@
+package integration
+
+import (
+	"testing"
+
+	"github.com/sourcenetwork/corekv"
+	"github.com/sourcenetwork/corekv/test/action"
+)
+
+func TestGetMany(t *testing.T) {
+	test := &Test{
+		Actions: []action.Action{
+			action.Set([]byte("k1"), []byte("v1")),
+			action.Set([]byte("k2"), nil),
+			action.Set([]byte("k3"), []byte("v3")),
+			action.Delete([]byte("k3")),
+			action.GetMany(
+				[][]byte{
+					[]byte("k3"),
+					[]byte("k1"),
+					[]byte("does not exist"),
+					[]byte("k2"),
+					[]byte("k1"),
+				},
+				[]corekv.GetResult{
+					{},
+					{Value: []byte("v1"), Found: true},
+					{},
+					{Found: true},
+					{Value: []byte("v1"), Found: true},
+				},
+			),
+		},
+	}
+
+	test.Execute(t)
+}
+
+func TestGetMany_NoKeys(t *testing.T) {
+	test := &Test{
+		Actions: []action.Action{
+			action.GetMany([][]byte{}, []corekv.GetResult{}),
+		},
+	}
+
+	test.Execute(t)
+}
+
+func TestGetMany_EmptyKey_Errors(t *testing.T) {
+	test := &Test{
+		Actions: []action.Action{
+			action.GetManyE([][]byte{[]byte("k1"), {}}, corekv.ErrEmptyKey.Error()),
+		},
+	}
+
+	test.Execute(t)
+}
+
+func TestHasMany(t *testing.T) {
+	test := &Test{
+		Actions: []action.Action{
+			action.Set([]byte("k1"), []byte("v1")),
+			action.Set([]byte("k2"), nil),
+			action.HasMany(
+				[][]byte{
+					[]byte("k2"),
+					[]byte("does not exist"),
+					[]byte("k1"),
+				},
+				[]bool{true, false, true},
+			),
+		},
+	}
+
+	test.Execute(t)
+}
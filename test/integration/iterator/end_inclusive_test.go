@@ -0,0 +1,82 @@
+package iterator
+
+import (
+	"testing"
+
+	"github.com/sourcenetwork/corekv"
+	"github.com/sourcenetwork/corekv/test/action"
+	"github.com/sourcenetwork/corekv/test/integration"
+)
+
+func TestIteratorEndInclusive(t *testing.T) {
+	test := &integration.Test{
+		Actions: []action.Action{
+			action.Set([]byte("k1"), []byte("v1")),
+			action.Set([]byte("k2"), []byte("v2")),
+			action.Set([]byte("k2\x00"), []byte("v2.0")),
+			action.Set([]byte("k3"), []byte("v3")),
+			&action.Iterate{
+				IterOptions: corekv.IterOptions{
+					End:          []byte("k2"),
+					EndInclusive: true,
+				},
+				Expected: []action.KeyValue{
+					{Key: []byte("k1"), Value: []byte("v1")},
+					{Key: []byte("k2"), Value: []byte("v2")},
+				},
+			},
+		},
+	}
+
+	test.Execute(t)
+}
+
+func TestIteratorEndInclusive_Reverse(t *testing.T) {
+	test := &integration.Test{
+		Actions: []action.Action{
+			action.Set([]byte("k1"), []byte("v1")),
+			action.Set([]byte("k2"), []byte("v2")),
+			action.Set([]byte("k3"), []byte("v3")),
+			&action.Iterator{
+				IterOptions: corekv.IterOptions{
+					End:          []byte("k2"),
+					EndInclusive: true,
+					Reverse:      true,
+				},
+				ChildActions: []action.IteratorAction{
+					action.Next(true),
+					action.Key([]byte("k2")),
+					action.Next(true),
+					action.Key([]byte("k1")),
+					action.Next(false),
+					action.Seek([]byte("k3"), true),
+					action.Key([]byte("k2")),
+				},
+			},
+		},
+	}
+
+	test.Execute(t)
+}
+
+func TestIteratorStartEndInclusive_SameKey(t *testing.T) {
+	test := &integration.Test{
+		Actions: []action.Action{
+			action.Set([]byte("k1"), []byte("v1")),
+			action.Set([]byte("k2"), []byte("v2")),
+			action.Set([]byte("k3"), []byte("v3")),
+			&action.Iterate{
+				IterOptions: corekv.IterOptions{
+					Start:        []byte("k2"),
+					End:          []byte("k2"),
+					EndInclusive: true,
+				},
+				Expected: []action.KeyValue{
+					{Key: []byte("k2"), Value: []byte("v2")},
+				},
+			},
+		},
+	}
+
+	test.Execute(t)
+}
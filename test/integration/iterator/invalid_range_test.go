@@ -0,0 +1,54 @@
+package iterator
+
+import (
+	"testing"
+
+	"github.com/sourcenetwork/corekv"
+	"github.com/sourcenetwork/corekv/test/action"
+	"github.com/sourcenetwork/corekv/test/integration"
+)
+
+func TestIteratorInvalidRange_Errors(t *testing.T) {
+	test := &integration.Test{
+		Actions: []action.Action{
+			action.Set([]byte("k1"), []byte("v1")),
+			action.Set([]byte("k2"), []byte("v2")),
+			&action.Iterate{
+				IterOptions: corekv.IterOptions{
+					Start: []byte("k2"),
+					End:   []byte("k1"),
+				},
+				Expected:      []action.KeyValue{},
+				ExpectedError: corekv.ErrInvalidRange.Error(),
+			},
+		},
+	}
+
+	test.Execute(t)
+}
+
+func TestIteratorStartEqualsEnd_YieldsNothing(t *testing.T) {
+	test := &integration.Test{
+		Actions: []action.Action{
+			action.Set([]byte("k1"), []byte("v1")),
+			action.Set([]byte("k2"), []byte("v2")),
+			&action.Iterate{
+				IterOptions: corekv.IterOptions{
+					Start: []byte("k1"),
+					End:   []byte("k1"),
+				},
+				Expected: []action.KeyValue{},
+			},
+			&action.Iterate{
+				IterOptions: corekv.IterOptions{
+					Start:   []byte("k1"),
+					End:     []byte("k1"),
+					Reverse: true,
+				},
+				Expected: []action.KeyValue{},
+			},
+		},
+	}
+
+	test.Execute(t)
+}
@@ -0,0 +1,80 @@
+package iterator
+
+import (
+	"testing"
+
+	"github.com/sourcenetwork/corekv"
+	"github.com/sourcenetwork/corekv/test/action"
+	"github.com/sourcenetwork/corekv/test/integration"
+)
+
+func TestIteratorKeysOnly(t *testing.T) {
+	test := &integration.Test{
+		Actions: []action.Action{
+			action.Set([]byte("k1"), []byte("v1")),
+			action.Set([]byte("k3"), nil),
+			action.Set([]byte("k2"), []byte("v2")),
+			&action.Iterate{
+				IterOptions: corekv.IterOptions{
+					KeysOnly: true,
+				},
+				Expected: []action.KeyValue{
+					{Key: []byte("k1"), Value: nil},
+					{Key: []byte("k2"), Value: nil},
+					{Key: []byte("k3"), Value: nil},
+				},
+			},
+		},
+	}
+
+	test.Execute(t)
+}
+
+func TestIteratorKeysOnlyPrefixReverse(t *testing.T) {
+	test := &integration.Test{
+		Actions: []action.Action{
+			action.Set([]byte("a1"), []byte("v1")),
+			action.Set([]byte("k1"), []byte("v1")),
+			action.Set([]byte("k2"), []byte("v2")),
+			action.Set([]byte("z1"), []byte("v1")),
+			&action.Iterate{
+				IterOptions: corekv.IterOptions{
+					Prefix:   []byte("k"),
+					Reverse:  true,
+					KeysOnly: true,
+				},
+				Expected: []action.KeyValue{
+					{Key: []byte("k2"), Value: nil},
+					{Key: []byte("k1"), Value: nil},
+				},
+			},
+		},
+	}
+
+	test.Execute(t)
+}
+
+func TestIteratorKeysOnlySeekValue(t *testing.T) {
+	test := &integration.Test{
+		Actions: []action.Action{
+			action.Set([]byte("k1"), []byte("v1")),
+			action.Set([]byte("k3"), []byte("v3")),
+			action.Set([]byte("k2"), []byte("v2")),
+			&action.Iterator{
+				IterOptions: corekv.IterOptions{
+					Start:    []byte("k1"),
+					End:      []byte("k3"),
+					KeysOnly: true,
+				},
+				ChildActions: []action.IteratorAction{
+					action.Seek([]byte("k2"), true),
+					action.Key([]byte("k2")),
+					action.Value(nil),
+					action.Next(false),
+				},
+			},
+		},
+	}
+
+	test.Execute(t)
+}
@@ -0,0 +1,110 @@
+package iterator
+
+import (
+	"testing"
+
+	"github.com/sourcenetwork/corekv"
+	"github.com/sourcenetwork/corekv/test/action"
+	"github.com/sourcenetwork/corekv/test/integration"
+)
+
+func TestIteratorLimit(t *testing.T) {
+	test := &integration.Test{
+		Actions: []action.Action{
+			action.Set([]byte("k1"), []byte("v1")),
+			action.Set([]byte("k2"), []byte("v2")),
+			action.Set([]byte("k3"), []byte("v3")),
+			&action.Iterate{
+				IterOptions: corekv.IterOptions{
+					Limit: 2,
+				},
+				Expected: []action.KeyValue{
+					{Key: []byte("k1"), Value: []byte("v1")},
+					{Key: []byte("k2"), Value: []byte("v2")},
+				},
+			},
+		},
+	}
+
+	test.Execute(t)
+}
+
+func TestIteratorLimit_ReversePrefix(t *testing.T) {
+	test := &integration.Test{
+		Actions: []action.Action{
+			action.Set([]byte("k1"), []byte("v1")),
+			action.Set([]byte("k2"), []byte("v2")),
+			action.Set([]byte("k3"), []byte("v3")),
+			action.Set([]byte("z1"), []byte("v1")),
+			&action.Iterate{
+				IterOptions: corekv.IterOptions{
+					Prefix:  []byte("k"),
+					Reverse: true,
+					Limit:   2,
+				},
+				Expected: []action.KeyValue{
+					{Key: []byte("k3"), Value: []byte("v3")},
+					{Key: []byte("k2"), Value: []byte("v2")},
+				},
+			},
+		},
+	}
+
+	test.Execute(t)
+}
+
+func TestIteratorLimit_GreaterThanItemCount(t *testing.T) {
+	test := &integration.Test{
+		Actions: []action.Action{
+			action.Set([]byte("k1"), []byte("v1")),
+			action.Set([]byte("k2"), []byte("v2")),
+			&action.Iterate{
+				IterOptions: corekv.IterOptions{
+					Limit: 5,
+				},
+				Expected: []action.KeyValue{
+					{Key: []byte("k1"), Value: []byte("v1")},
+					{Key: []byte("k2"), Value: []byte("v2")},
+				},
+			},
+		},
+	}
+
+	test.Execute(t)
+}
+
+func TestIteratorLimit_ResetSeekPrev(t *testing.T) {
+	test := &integration.Test{
+		Actions: []action.Action{
+			action.Set([]byte("k1"), []byte("v1")),
+			action.Set([]byte("k2"), []byte("v2")),
+			action.Set([]byte("k3"), []byte("v3")),
+			action.Set([]byte("k4"), []byte("v4")),
+			&action.Iterator{
+				IterOptions: corekv.IterOptions{
+					Limit: 2,
+				},
+				ChildActions: []action.IteratorAction{
+					action.Next(true),
+					action.Next(true),
+					action.Peek(nil, false),
+					action.Next(false),
+					action.Prev(true),
+					action.Key([]byte("k2")),
+					action.Reset(),
+					action.Next(true),
+					action.Key([]byte("k1")),
+					// The limit applies from the item moved to by `Seek`
+					action.Seek([]byte("k3"), true),
+					action.Next(true),
+					action.Key([]byte("k4")),
+					action.Prev(true),
+					action.Key([]byte("k3")),
+					action.Prev(false),
+				},
+			},
+		},
+	}
+
+	test.Execute(t)
+}
@@ -0,0 +1,56 @@
+package iterator
+
+import (
+	"testing"
+
+	"github.com/sourcenetwork/corekv"
+	"github.com/sourcenetwork/corekv/test/action"
+	"github.com/sourcenetwork/corekv/test/integration"
+)
+
+func TestIteratorNamespaceStart(t *testing.T) {
+	test := &integration.Test{
+		Actions: []action.Action{
+			action.Set([]byte("a1"), []byte("outside")),
+			action.Set([]byte("z1"), []byte("outside")),
+			action.Namespace([]byte("ns")),
+			action.Set([]byte("k1"), []byte("v1")),
+			action.Set([]byte("k2"), []byte("v2")),
+			&action.Iterate{
+				IterOptions: corekv.IterOptions{
+					Start: []byte("k2"),
+				},
+				Expected: []action.KeyValue{
+					// Items beyond the namespace must not be yielded
+					{Key: []byte("k2"), Value: []byte("v2")},
+				},
+			},
+		},
+	}
+
+	test.Execute(t)
+}
+
+func TestIteratorNamespaceEnd_Reverse(t *testing.T) {
+	test := &integration.Test{
+		Actions: []action.Action{
+			action.Set([]byte("a1"), []byte("outside")),
+			action.Set([]byte("z1"), []byte("outside")),
+			action.Namespace([]byte("ns")),
+			action.Set([]byte("k1"), []byte("v1")),
+			action.Set([]byte("k2"), []byte("v2")),
+			&action.Iterate{
+				IterOptions: corekv.IterOptions{
+					End:     []byte("k2"),
+					Reverse: true,
+				},
+				Expected: []action.KeyValue{
+					// Items before the namespace must not be yielded
+					{Key: []byte("k1"), Value: []byte("v1")},
+				},
+			},
+		},
+	}
+
+	test.Execute(t)
+}
@@ -0,0 +1,74 @@
+package iterator
+
+import (
+	"testing"
+
+	"github.com/sourcenetwork/corekv"
+	"github.com/sourcenetwork/corekv/test/action"
+	"github.com/sourcenetwork/corekv/test/integration"
+)
+
+func TestIteratorOffset(t *testing.T) {
+	test := &integration.Test{
+		Actions: []action.Action{
+			action.Set([]byte("k1"), []byte("v1")),
+			action.Set([]byte("k2"), []byte("v2")),
+			action.Set([]byte("k3"), []byte("v3")),
+			&action.Iterate{
+				IterOptions: corekv.IterOptions{
+					Offset: 1,
+				},
+				Expected: []action.KeyValue{
+					{Key: []byte("k2"), Value: []byte("v2")},
+					{Key: []byte("k3"), Value: []byte("v3")},
+				},
+			},
+		},
+	}
+
+	test.Execute(t)
+}
+
+func TestIteratorOffsetLimit_Reverse(t *testing.T) {
+	test := &integration.Test{
+		Actions: []action.Action{
+			action.Set([]byte("k1"), []byte("v1")),
+			action.Set([]byte("k2"), []byte("v2")),
+			action.Set([]byte("k3"), []byte("v3")),
+			action.Set([]byte("k4"), []byte("v4")),
+			&action.Iterate{
+				IterOptions: corekv.IterOptions{
+					Reverse: true,
+					Offset:  1,
+					Limit:   2,
+				},
+				Expected: []action.KeyValue{
+					{Key: []byte("k3"), Value: []byte("v3")},
+					{Key: []byte("k2"), Value: []byte("v2")},
+				},
+			},
+		},
+	}
+
+	test.Execute(t)
+}
+
+func TestIteratorOffset_GreaterThanItemCount(t *testing.T) {
+	test := &integration.Test{
+		Actions: []action.Action{
+			action.Set([]byte("k1"), []byte("v1")),
+			&action.Iterator{
+				IterOptions: corekv.IterOptions{
+					Offset: 2,
+				},
+				ChildActions: []action.IteratorAction{
+					action.Peek(nil, false),
+					action.Next(false),
+					action.Prev(false),
+				},
+			},
+		},
+	}
+
+	test.Execute(t)
+}
@@ -0,0 +1,77 @@
+package iterator
+
+import (
+	"testing"
+
+	"github.com/sourcenetwork/corekv"
+	"github.com/sourcenetwork/corekv/test/action"
+	"github.com/sourcenetwork/corekv/test/integration"
+)
+
+func TestIteratorPeek(t *testing.T) {
+	test := &integration.Test{
+		Actions: []action.Action{
+			action.Set([]byte("k1"), []byte("v1")),
+			action.Set([]byte("k2"), []byte("v2")),
+			&action.Iterator{
+				ChildActions: []action.IteratorAction{
+					action.Peek([]byte("k1"), true),
+					action.Next(true),
+					action.Key([]byte("k1")),
+					action.Peek([]byte("k2"), true),
+					action.Key([]byte("k1")),
+					action.Value([]byte("v1")),
+					action.Next(true),
+					action.Key([]byte("k2")),
+					action.Peek(nil, false),
+					action.Key([]byte("k2")),
+					action.Next(false),
+					action.Peek(nil, false),
+				},
+			},
+		},
+	}
+
+	test.Execute(t)
+}
+
+func TestIteratorPeek_Empty(t *testing.T) {
+	test := &integration.Test{
+		Actions: []action.Action{
+			&action.Iterator{
+				ChildActions: []action.IteratorAction{
+					action.Peek(nil, false),
+					action.Next(false),
+				},
+			},
+		},
+	}
+
+	test.Execute(t)
+}
+
+func TestIteratorPeek_Reverse(t *testing.T) {
+	test := &integration.Test{
+		Actions: []action.Action{
+			action.Set([]byte("k1"), []byte("v1")),
+			action.Set([]byte("k2"), []byte("v2")),
+			action.Set([]byte("k3"), []byte("v3")),
+			&action.Iterator{
+				IterOptions: corekv.IterOptions{
+					Reverse: true,
+					End:     []byte("k3"),
+				},
+				ChildActions: []action.IteratorAction{
+					action.Peek([]byte("k2"), true),
+					action.Next(true),
+					action.Peek([]byte("k1"), true),
+					action.Next(true),
+					action.Key([]byte("k1")),
+					action.Peek(nil, false),
+				},
+			},
+		},
+	}
+
+	test.Execute(t)
+}
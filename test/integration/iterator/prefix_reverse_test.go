@@ -32,3 +32,61 @@ func TestIteratorPrefixReverse(t *testing.T) {
 
 	test.Execute(t)
 }
+
+func TestIteratorPrefixReverse_PrefixEndExists(t *testing.T) {
+	test := &integration.Test{
+		Actions: []action.Action{
+			action.Set([]byte("a"), []byte("va")),
+			action.Set([]byte("b"), []byte("vb")),
+			action.Set([]byte("ba"), []byte("vba")),
+			// `c` is the end of the prefix `b`, the reverse iteration starts from it
+			action.Set([]byte("c"), []byte("vc")),
+			&action.Iterate{
+				IterOptions: corekv.IterOptions{
+					Reverse: true,
+					Prefix:  []byte("b"),
+				},
+				Expected: []action.KeyValue{
+					{Key: []byte("ba"), Value: []byte("vba")},
+					{Key: []byte("b"), Value: []byte("vb")},
+				},
+			},
+		},
+	}
+
+	test.Execute(t)
+}
+
+func TestIteratorPrefixReverse_PrefixEndExistsWithLimit(t *testing.T) {
+	test := &integration.Test{
+		Actions: []action.Action{
+			action.Set([]byte("a"), []byte("va")),
+			action.Set([]byte("b"), []byte("vb")),
+			action.Set([]byte("ba"), []byte("vba")),
+			action.Set([]byte("c"), []byte("vc")),
+			&action.Iterate{
+				IterOptions: corekv.IterOptions{
+					Reverse: true,
+					Prefix:  []byte("b"),
+					Limit:   1,
+				},
+				Expected: []action.KeyValue{
+					{Key: []byte("ba"), Value: []byte("vba")},
+				},
+			},
+			&action.Iterate{
+				IterOptions: corekv.IterOptions{
+					Reverse:     true,
+					Prefix:      []byte("b"),
+					Limit:       1,
+					ResumeToken: corekv.NewResumeToken([]byte("ba"), true),
+				},
+				Expected: []action.KeyValue{
+					{Key: []byte("b"), Value: []byte("vb")},
+				},
+			},
+		},
+	}
+
+	test.Execute(t)
+}
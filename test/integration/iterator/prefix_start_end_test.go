@@ -0,0 +1,84 @@
+package iterator
+
+import (
+	"testing"
+
+	"github.com/sourcenetwork/corekv"
+	"github.com/sourcenetwork/corekv/test/action"
+	"github.com/sourcenetwork/corekv/test/integration"
+)
+
+func TestIteratorPrefixStartEnd(t *testing.T) {
+	test := &integration.Test{
+		Actions: []action.Action{
+			action.Set([]byte("a3"), []byte("v3")),
+			action.Set([]byte("k1"), []byte("v1")),
+			action.Set([]byte("k2"), []byte("v2")),
+			action.Set([]byte("k3"), []byte("v3")),
+			action.Set([]byte("k4"), []byte("v4")),
+			action.Set([]byte("z3"), []byte("v3")),
+			&action.Iterate{
+				IterOptions: corekv.IterOptions{
+					Prefix: []byte("k"),
+					Start:  []byte("k2"),
+					End:    []byte("k4"),
+				},
+				Expected: []action.KeyValue{
+					{Key: []byte("k2"), Value: []byte("v2")},
+					{Key: []byte("k3"), Value: []byte("v3")},
+				},
+			},
+		},
+	}
+
+	test.Execute(t)
+}
+
+func TestIteratorPrefixStart_Reverse(t *testing.T) {
+	test := &integration.Test{
+		Actions: []action.Action{
+			action.Set([]byte("a3"), []byte("v3")),
+			action.Set([]byte("k1"), []byte("v1")),
+			action.Set([]byte("k2"), []byte("v2")),
+			action.Set([]byte("k3"), []byte("v3")),
+			action.Set([]byte("z3"), []byte("v3")),
+			&action.Iterate{
+				IterOptions: corekv.IterOptions{
+					Prefix:  []byte("k"),
+					Start:   []byte("k2"),
+					Reverse: true,
+				},
+				Expected: []action.KeyValue{
+					// `z3` is outside of the prefix and must not be yielded
+					{Key: []byte("k3"), Value: []byte("v3")},
+					{Key: []byte("k2"), Value: []byte("v2")},
+				},
+			},
+		},
+	}
+
+	test.Execute(t)
+}
+
+func TestIteratorPrefixEnd_OutsidePrefix(t *testing.T) {
+	test := &integration.Test{
+		Actions: []action.Action{
+			action.Set([]byte("a3"), []byte("v3")),
+			action.Set([]byte("k1"), []byte("v1")),
+			action.Set([]byte("k2"), []byte("v2")),
+			action.Set([]byte("z3"), []byte("v3")),
+			&action.Iterate{
+				IterOptions: corekv.IterOptions{
+					Prefix: []byte("k"),
+					End:    []byte("z9"),
+				},
+				Expected: []action.KeyValue{
+					{Key: []byte("k1"), Value: []byte("v1")},
+					{Key: []byte("k2"), Value: []byte("v2")},
+				},
+			},
+		},
+	}
+
+	test.Execute(t)
+}
@@ -0,0 +1,219 @@
+package iterator
+
+import (
+	"testing"
+
+	"github.com/sourcenetwork/corekv"
+	"github.com/sourcenetwork/corekv/test/action"
+	"github.com/sourcenetwork/corekv/test/integration"
+)
+
+func TestIteratorPrev(t *testing.T) {
+	test := &integration.Test{
+		Actions: []action.Action{
+			action.Set([]byte("k1"), []byte("v1")),
+			action.Set([]byte("k3"), []byte("v3")),
+			action.Set([]byte("k2"), []byte("v2")),
+			&action.Iterator{
+				ChildActions: []action.IteratorAction{
+					action.Next(true),
+					action.Next(true),
+					action.Next(true),
+					action.Key([]byte("k3")),
+					action.Prev(true),
+					action.Key([]byte("k2")),
+					action.Value([]byte("v2")),
+					action.Prev(true),
+					action.Key([]byte("k1")),
+					action.Next(true),
+					action.Key([]byte("k2")),
+					action.Next(true),
+					action.Key([]byte("k3")),
+				},
+			},
+		},
+	}
+
+	test.Execute(t)
+}
+
+func TestIteratorPrev_BeforeFirst(t *testing.T) {
+	test := &integration.Test{
+		Actions: []action.Action{
+			action.Set([]byte("k1"), []byte("v1")),
+			action.Set([]byte("k2"), []byte("v2")),
+			&action.Iterator{
+				ChildActions: []action.IteratorAction{
+					// The iterator has not yet been moved, there is nothing before it.
+					action.Prev(false),
+					action.Next(true),
+					action.Key([]byte("k1")),
+					action.Prev(false),
+					// Moving backwards past the first item returns the iterator to
+					// the beginning.
+					action.Next(true),
+					action.Key([]byte("k1")),
+				},
+			},
+		},
+	}
+
+	test.Execute(t)
+}
+
+func TestIteratorPrev_AfterLast(t *testing.T) {
+	test := &integration.Test{
+		Actions: []action.Action{
+			action.Set([]byte("k1"), []byte("v1")),
+			action.Set([]byte("k2"), []byte("v2")),
+			&action.Iterator{
+				ChildActions: []action.IteratorAction{
+					action.Next(true),
+					action.Next(true),
+					action.Next(false),
+					action.Prev(true),
+					action.Key([]byte("k2")),
+					action.Value([]byte("v2")),
+					action.Next(false),
+				},
+			},
+		},
+	}
+
+	test.Execute(t)
+}
+
+func TestIteratorPrev_Reverse(t *testing.T) {
+	test := &integration.Test{
+		Actions: []action.Action{
+			action.Set([]byte("k1"), []byte("v1")),
+			action.Set([]byte("k3"), []byte("v3")),
+			action.Set([]byte("k2"), []byte("v2")),
+			&action.Iterator{
+				IterOptions: corekv.IterOptions{
+					Reverse: true,
+				},
+				ChildActions: []action.IteratorAction{
+					action.Next(true),
+					action.Next(true),
+					action.Key([]byte("k2")),
+					action.Prev(true),
+					action.Key([]byte("k3")),
+					action.Prev(false),
+					action.Next(true),
+					action.Key([]byte("k3")),
+				},
+			},
+		},
+	}
+
+	test.Execute(t)
+}
+
+func TestIteratorPrev_StartEnd(t *testing.T) {
+	test := &integration.Test{
+		Actions: []action.Action{
+			action.Set([]byte("k1"), []byte("v1")),
+			action.Set([]byte("k2"), []byte("v2")),
+			action.Set([]byte("k3"), []byte("v3")),
+			action.Set([]byte("k4"), []byte("v4")),
+			&action.Iterator{
+				IterOptions: corekv.IterOptions{
+					Start: []byte("k2"),
+					End:   []byte("k4"),
+				},
+				ChildActions: []action.IteratorAction{
+					action.Next(true),
+					action.Next(true),
+					action.Next(false),
+					// `k4` is outside of the iterator's bounds and must not be yielded
+					action.Prev(true),
+					action.Key([]byte("k3")),
+					action.Prev(true),
+					action.Key([]byte("k2")),
+					// `k1` is outside of the iterator's bounds and must not be yielded
+					action.Prev(false),
+				},
+			},
+		},
+	}
+
+	test.Execute(t)
+}
+
+func TestIteratorPrev_ReversePrefix(t *testing.T) {
+	test := &integration.Test{
+		Actions: []action.Action{
+			action.Set([]byte("a1"), []byte("v1")),
+			action.Set([]byte("k1"), []byte("v1")),
+			action.Set([]byte("k2"), []byte("v2")),
+			action.Set([]byte("z1"), []byte("v1")),
+			&action.Iterator{
+				IterOptions: corekv.IterOptions{
+					Prefix:  []byte("k"),
+					Reverse: true,
+				},
+				ChildActions: []action.IteratorAction{
+					action.Next(true),
+					action.Next(true),
+					action.Next(false),
+					action.Prev(true),
+					action.Key([]byte("k1")),
+					action.Prev(true),
+					action.Key([]byte("k2")),
+					action.Prev(false),
+				},
+			},
+		},
+	}
+
+	test.Execute(t)
+}
+
+func TestIteratorPrev_SkipsDeleted(t *testing.T) {
+	test := &integration.Test{
+		Actions: []action.Action{
+			action.Set([]byte("k1"), []byte("v1")),
+			action.Set([]byte("k2"), []byte("v2")),
+			action.Set([]byte("k3"), []byte("v3")),
+			action.Delete([]byte("k2")),
+			&action.Iterator{
+				ChildActions: []action.IteratorAction{
+					action.Seek([]byte("k3"), true),
+					action.Prev(true),
+					action.Key([]byte("k1")),
+				},
+			},
+		},
+	}
+
+	test.Execute(t)
+}
+
+func TestIteratorPrev_PrefixEndExists(t *testing.T) {
+	test := &integration.Test{
+		Actions: []action.Action{
+			action.Set([]byte("a"), []byte("va")),
+			action.Set([]byte("b"), []byte("vb")),
+			action.Set([]byte("ba"), []byte("vba")),
+			action.Set([]byte("c"), []byte("vc")),
+			&action.Iterator{
+				IterOptions: corekv.IterOptions{
+					Prefix: []byte("b"),
+				},
+				ChildActions: []action.IteratorAction{
+					action.Next(true),
+					action.Next(true),
+					action.Next(false),
+					// The iterator has been exhausted, so moving backwards yields the last item
+					action.Prev(true),
+					action.Key([]byte("ba")),
+					action.Prev(true),
+					action.Key([]byte("b")),
+				},
+			},
+		},
+	}
+
+	test.Execute(t)
+}
@@ -0,0 +1,165 @@
+package iterator
+
+import (
+	"testing"
+
+	"github.com/sourcenetwork/corekv"
+	"github.com/sourcenetwork/corekv/test/action"
+	"github.com/sourcenetwork/corekv/test/integration"
+)
+
+func TestIteratorResumeToken(t *testing.T) {
+	test := &integration.Test{
+		Actions: []action.Action{
+			action.Set([]byte("k1"), []byte("v1")),
+			action.Set([]byte("k2"), []byte("v2")),
+			action.Set([]byte("k3"), []byte("v3")),
+			action.Set([]byte("k4"), []byte("v4")),
+			action.Set([]byte("k5"), []byte("v5")),
+			&action.Iterate{
+				IterOptions: corekv.IterOptions{
+					Limit: 2,
+				},
+				Expected: []action.KeyValue{
+					{Key: []byte("k1"), Value: []byte("v1")},
+					{Key: []byte("k2"), Value: []byte("v2")},
+				},
+			},
+			&action.Iterate{
+				IterOptions: corekv.IterOptions{
+					Limit:       2,
+					ResumeToken: corekv.NewResumeToken([]byte("k2"), false),
+				},
+				Expected: []action.KeyValue{
+					{Key: []byte("k3"), Value: []byte("v3")},
+					{Key: []byte("k4"), Value: []byte("v4")},
+				},
+			},
+			&action.Iterate{
+				IterOptions: corekv.IterOptions{
+					Limit:       2,
+					ResumeToken: corekv.NewResumeToken([]byte("k4"), false),
+				},
+				Expected: []action.KeyValue{
+					{Key: []byte("k5"), Value: []byte("v5")},
+				},
+			},
+		},
+	}
+
+	test.Execute(t)
+}
+
+func TestIteratorResumeToken_ReversePrefix(t *testing.T) {
+	test := &integration.Test{
+		Actions: []action.Action{
+			action.Set([]byte("a1"), []byte("v1")),
+			action.Set([]byte("k1"), []byte("v1")),
+			action.Set([]byte("k2"), []byte("v2")),
+			action.Set([]byte("k3"), []byte("v3")),
+			action.Set([]byte("z1"), []byte("v1")),
+			&action.Iterate{
+				IterOptions: corekv.IterOptions{
+					Prefix:      []byte("k"),
+					Reverse:     true,
+					Limit:       1,
+					ResumeToken: corekv.NewResumeToken([]byte("k3"), true),
+				},
+				Expected: []action.KeyValue{
+					{Key: []byte("k2"), Value: []byte("v2")},
+				},
+			},
+			&action.Iterate{
+				IterOptions: corekv.IterOptions{
+					Prefix:      []byte("k"),
+					Reverse:     true,
+					ResumeToken: corekv.NewResumeToken([]byte("k2"), true),
+				},
+				Expected: []action.KeyValue{
+					// `a1` is outside of the prefix and must not be yielded
+					{Key: []byte("k1"), Value: []byte("v1")},
+				},
+			},
+		},
+	}
+
+	test.Execute(t)
+}
+
+func TestIteratorResumeToken_DeletedKey(t *testing.T) {
+	test := &integration.Test{
+		Actions: []action.Action{
+			action.Set([]byte("k1"), []byte("v1")),
+			action.Set([]byte("k2"), []byte("v2")),
+			action.Set([]byte("k3"), []byte("v3")),
+			// The key the token was created with no longer needs to exist
+			action.Delete([]byte("k2")),
+			&action.Iterate{
+				IterOptions: corekv.IterOptions{
+					ResumeToken: corekv.NewResumeToken([]byte("k2"), false),
+				},
+				Expected: []action.KeyValue{
+					{Key: []byte("k3"), Value: []byte("v3")},
+				},
+			},
+		},
+	}
+
+	test.Execute(t)
+}
+
+func TestIteratorResumeToken_Namespace(t *testing.T) {
+	test := &integration.Test{
+		Actions: []action.Action{
+			action.Set([]byte("z1"), []byte("outside")),
+			action.Namespace([]byte("ns")),
+			action.Set([]byte("k1"), []byte("v1")),
+			action.Set([]byte("k2"), []byte("v2")),
+			&action.Iterate{
+				IterOptions: corekv.IterOptions{
+					ResumeToken: corekv.NewResumeToken([]byte("k1"), false),
+				},
+				Expected: []action.KeyValue{
+					{Key: []byte("k2"), Value: []byte("v2")},
+				},
+			},
+		},
+	}
+
+	test.Execute(t)
+}
+
+func TestIteratorResumeToken_DirectionMismatch_Errors(t *testing.T) {
+	test := &integration.Test{
+		Actions: []action.Action{
+			action.Set([]byte("k1"), []byte("v1")),
+			&action.Iterate{
+				IterOptions: corekv.IterOptions{
+					Reverse:     true,
+					ResumeToken: corekv.NewResumeToken([]byte("k1"), false),
+				},
+				Expected:      []action.KeyValue{},
+				ExpectedError: corekv.ErrInvalidResumeToken.Error(),
+			},
+		},
+	}
+
+	test.Execute(t)
+}
+
+func TestIteratorResumeToken_Malformed_Errors(t *testing.T) {
+	test := &integration.Test{
+		Actions: []action.Action{
+			action.Set([]byte("k1"), []byte("v1")),
+			&action.Iterate{
+				IterOptions: corekv.IterOptions{
+					ResumeToken: []byte("k1"),
+				},
+				Expected:      []action.KeyValue{},
+				ExpectedError: corekv.ErrInvalidResumeToken.Error(),
+			},
+		},
+	}
+
+	test.Execute(t)
+}
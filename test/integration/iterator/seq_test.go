@@ -0,0 +1,87 @@
+//go:build go1.23
+
+package iterator
+
+import (
+	"testing"
+
+	"github.com/sourcenetwork/corekv"
+	"github.com/sourcenetwork/corekv/test/action"
+	"github.com/sourcenetwork/corekv/test/integration"
+)
+
+func TestIteratorSeq(t *testing.T) {
+	test := &integration.Test{
+		Actions: []action.Action{
+			action.Set([]byte("k1"), []byte("v1")),
+			action.Set([]byte("k3"), nil),
+			action.Set([]byte("k2"), []byte("v2")),
+			&action.IterateSeq{
+				Expected: []action.KeyValue{
+					{Key: []byte("k1"), Value: []byte("v1")},
+					{Key: []byte("k2"), Value: []byte("v2")},
+					{Key: []byte("k3"), Value: nil},
+				},
+			},
+		},
+	}
+
+	test.Execute(t)
+}
+
+func TestIteratorSeq_Break(t *testing.T) {
+	test := &integration.Test{
+		Actions: []action.Action{
+			action.Set([]byte("k1"), []byte("v1")),
+			action.Set([]byte("k3"), nil),
+			action.Set([]byte("k2"), []byte("v2")),
+			&action.IterateSeq{
+				IterOptions: corekv.IterOptions{
+					Reverse: true,
+				},
+				Take: 2,
+				Expected: []action.KeyValue{
+					{Key: []byte("k3"), Value: nil},
+					{Key: []byte("k2"), Value: []byte("v2")},
+				},
+			},
+			// The store must remain writable after breaking out of the loop, this
+			// would not be the case if the underlying iterator was not closed.
+			action.Set([]byte("k4"), []byte("v4")),
+			&action.IterateSeq{
+				IterOptions: corekv.IterOptions{
+					Prefix: []byte("k"),
+				},
+				Expected: []action.KeyValue{
+					{Key: []byte("k1"), Value: []byte("v1")},
+					{Key: []byte("k2"), Value: []byte("v2")},
+					{Key: []byte("k3"), Value: nil},
+					{Key: []byte("k4"), Value: []byte("v4")},
+				},
+			},
+		},
+	}
+
+	test.Execute(t)
+}
+
+func TestIteratorKeysSeq(t *testing.T) {
+	test := &integration.Test{
+		Actions: []action.Action{
+			action.Set([]byte("k1"), []byte("v1")),
+			action.Set([]byte("k3"), nil),
+			action.Set([]byte("k2"), []byte("v2")),
+			&action.IterateKeysSeq{
+				IterOptions: corekv.IterOptions{
+					Start: []byte("k2"),
+				},
+				Expected: [][]byte{
+					[]byte("k2"),
+					[]byte("k3"),
+				},
+			},
+		},
+	}
+
+	test.Execute(t)
+}
@@ -0,0 +1,56 @@
+package iterator
+
+import (
+	"testing"
+
+	"github.com/sourcenetwork/corekv"
+	"github.com/sourcenetwork/corekv/test/action"
+	"github.com/sourcenetwork/corekv/test/integration"
+)
+
+func TestIteratorStartExclusive(t *testing.T) {
+	test := &integration.Test{
+		Actions: []action.Action{
+			action.Set([]byte("k1"), []byte("v1")),
+			action.Set([]byte("k2"), []byte("v2")),
+			action.Set([]byte("k2\x00"), []byte("v2.0")),
+			action.Set([]byte("k3"), []byte("v3")),
+			&action.Iterate{
+				IterOptions: corekv.IterOptions{
+					Start:          []byte("k2"),
+					StartExclusive: true,
+				},
+				Expected: []action.KeyValue{
+					// `k2` is excluded by the start bound and must not be yielded
+					{Key: []byte("k2\x00"), Value: []byte("v2.0")},
+					{Key: []byte("k3"), Value: []byte("v3")},
+				},
+			},
+		},
+	}
+
+	test.Execute(t)
+}
+
+func TestIteratorStartExclusive_Reverse(t *testing.T) {
+	test := &integration.Test{
+		Actions: []action.Action{
+			action.Set([]byte("k1"), []byte("v1")),
+			action.Set([]byte("k2"), []byte("v2")),
+			action.Set([]byte("k3"), []byte("v3")),
+			&action.Iterate{
+				IterOptions: corekv.IterOptions{
+					Start:          []byte("k1"),
+					StartExclusive: true,
+					Reverse:        true,
+				},
+				Expected: []action.KeyValue{
+					{Key: []byte("k3"), Value: []byte("v3")},
+					{Key: []byte("k2"), Value: []byte("v2")},
+				},
+			},
+		},
+	}
+
+	test.Execute(t)
+}
@@ -0,0 +1,175 @@
+package integration
+
+import (
+	"testing"
+
+	"github.com/sourcenetwork/corekv"
+	"github.com/sourcenetwork/corekv/merge"
+	"github.com/sourcenetwork/corekv/test/action"
+)
+
+func TestMergedIterate(t *testing.T) {
+	test := &Test{
+		Actions: []action.Action{
+			action.Set([]byte("hot/k1"), []byte("hot1")),
+			action.Set([]byte("hot/k4"), []byte("hot4")),
+			action.Set([]byte("cold/k2"), []byte("cold2")),
+			action.Set([]byte("cold/k3"), []byte("cold3")),
+			action.Set([]byte("cold/k5"), []byte("cold5")),
+			&action.MergedIterate{
+				Namespaces: [][]byte{[]byte("hot/"), []byte("cold/")},
+				Expected: []action.KeyValue{
+					{Key: []byte("k1"), Value: []byte("hot1")},
+					{Key: []byte("k2"), Value: []byte("cold2")},
+					{Key: []byte("k3"), Value: []byte("cold3")},
+					{Key: []byte("k4"), Value: []byte("hot4")},
+					{Key: []byte("k5"), Value: []byte("cold5")},
+				},
+			},
+		},
+	}
+
+	test.Execute(t)
+}
+
+func TestMergedIterate_Reverse(t *testing.T) {
+	test := &Test{
+		Actions: []action.Action{
+			action.Set([]byte("hot/k1"), []byte("hot1")),
+			action.Set([]byte("hot/k4"), []byte("hot4")),
+			action.Set([]byte("cold/k2"), []byte("cold2")),
+			action.Set([]byte("cold/k3"), []byte("cold3")),
+			&action.MergedIterate{
+				IterOptions: corekv.IterOptions{
+					Reverse: true,
+				},
+				Namespaces: [][]byte{[]byte("hot/"), []byte("cold/")},
+				Expected: []action.KeyValue{
+					{Key: []byte("k4"), Value: []byte("hot4")},
+					{Key: []byte("k3"), Value: []byte("cold3")},
+					{Key: []byte("k2"), Value: []byte("cold2")},
+					{Key: []byte("k1"), Value: []byte("hot1")},
+				},
+			},
+		},
+	}
+
+	test.Execute(t)
+}
+
+func TestMergedIterate_TieFirstWins(t *testing.T) {
+	test := &Test{
+		Actions: []action.Action{
+			action.Set([]byte("hot/k1"), []byte("hot1")),
+			action.Set([]byte("hot/k2"), []byte("hot2")),
+			action.Set([]byte("cold/k1"), []byte("cold1")),
+			action.Set([]byte("cold/k3"), []byte("cold3")),
+			&action.MergedIterate{
+				Namespaces: [][]byte{[]byte("hot/"), []byte("cold/")},
+				Expected: []action.KeyValue{
+					{Key: []byte("k1"), Value: []byte("hot1")},
+					{Key: []byte("k2"), Value: []byte("hot2")},
+					{Key: []byte("k3"), Value: []byte("cold3")},
+				},
+			},
+		},
+	}
+
+	test.Execute(t)
+}
+
+func TestMergedIterate_TieLastWins(t *testing.T) {
+	test := &Test{
+		Actions: []action.Action{
+			action.Set([]byte("a/k1"), []byte("a1")),
+			action.Set([]byte("b/k1"), []byte("b1")),
+			action.Set([]byte("c/k1"), []byte("c1")),
+			action.Set([]byte("c/k2"), []byte("c2")),
+			action.Set([]byte("a/k2"), []byte("a2")),
+			&action.MergedIterate{
+				IterOptions: corekv.IterOptions{
+					Reverse: true,
+				},
+				Namespaces: [][]byte{[]byte("a/"), []byte("b/"), []byte("c/")},
+				TieBreaker: merge.LastWins,
+				Expected: []action.KeyValue{
+					{Key: []byte("k2"), Value: []byte("c2")},
+					{Key: []byte("k1"), Value: []byte("c1")},
+				},
+			},
+		},
+	}
+
+	test.Execute(t)
+}
+
+func TestMergedIterate_PrefixLimit(t *testing.T) {
+	test := &Test{
+		Actions: []action.Action{
+			action.Set([]byte("hot/k1"), []byte("hot1")),
+			action.Set([]byte("hot/x1"), []byte("hotx")),
+			action.Set([]byte("cold/k1"), []byte("cold1")),
+			action.Set([]byte("cold/k2"), []byte("cold2")),
+			action.Set([]byte("cold/k3"), []byte("cold3")),
+			&action.MergedIterate{
+				IterOptions: corekv.IterOptions{
+					Prefix: []byte("k"),
+					Limit:  2,
+				},
+				Namespaces: [][]byte{[]byte("hot/"), []byte("cold/")},
+				Expected: []action.KeyValue{
+					{Key: []byte("k1"), Value: []byte("hot1")},
+					{Key: []byte("k2"), Value: []byte("cold2")},
+				},
+			},
+		},
+	}
+
+	test.Execute(t)
+}
+
+func TestMergedIterator_SeekReset(t *testing.T) {
+	test := &Test{
+		Actions: []action.Action{
+			action.Set([]byte("hot/k1"), []byte("hot1")),
+			action.Set([]byte("hot/k3"), []byte("hot3")),
+			action.Set([]byte("cold/k2"), []byte("cold2")),
+			action.Set([]byte("cold/k3"), []byte("cold3")),
+			action.Set([]byte("cold/k4"), []byte("cold4")),
+			&action.MergedIterator{
+				Namespaces: [][]byte{[]byte("hot/"), []byte("cold/")},
+				ChildActions: []action.IteratorAction{
+					action.Seek([]byte("k2"), true),
+					action.Key([]byte("k2")),
+					action.Value([]byte("cold2")),
+					action.Next(true),
+					action.Key([]byte("k3")),
+					action.Value([]byte("hot3")),
+					action.Next(true),
+					action.Key([]byte("k4")),
+					action.Next(false),
+					action.Reset(),
+					action.Next(true),
+					action.Key([]byte("k1")),
+					action.Value([]byte("hot1")),
+				},
+			},
+		},
+	}
+
+	test.Execute(t)
+}
+
+func TestMergedIterate_Empty(t *testing.T) {
+	test := &Test{
+		Actions: []action.Action{
+			action.Set([]byte("other/k1"), []byte("v1")),
+			&action.MergedIterate{
+				Namespaces: [][]byte{[]byte("hot/"), []byte("cold/")},
+				Expected:   []action.KeyValue{},
+			},
+		},
+	}
+
+	test.Execute(t)
+}
@@ -0,0 +1,226 @@
+package metrics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/sourcenetwork/corekv"
+	"github.com/sourcenetwork/corekv/metrics"
+	"github.com/sourcenetwork/corekv/test/action"
+	"github.com/sourcenetwork/corekv/test/integration"
+)
+
+func TestMetrics_Operations(t *testing.T) {
+	test := &integration.Test{
+		Actions: []action.Action{
+			action.Metrics(),
+			action.Set([]byte("k1"), []byte("v1")),
+			action.Get([]byte("k1"), []byte("v1")),
+			action.GetE([]byte("k2"), corekv.ErrNotFound.Error()),
+			action.Has([]byte("k1"), true),
+			action.Delete([]byte("k1")),
+			action.Operations(metrics.OpSet, metrics.ErrorClassNone, 1),
+			action.Operations(metrics.OpGet, metrics.ErrorClassNone, 1),
+			action.Operations(metrics.OpGet, metrics.ErrorClassNotFound, 1),
+			action.Operations(metrics.OpHas, metrics.ErrorClassNone, 1),
+			action.Operations(metrics.OpDelete, metrics.ErrorClassNone, 1),
+		},
+	}
+
+	test.Execute(t)
+}
+
+func TestMetrics_Iterator(t *testing.T) {
+	test := &integration.Test{
+		Actions: []action.Action{
+			action.Set([]byte("k1"), []byte("v1")),
+			action.Set([]byte("k2"), []byte("v2")),
+			action.Metrics(),
+			&action.Iterate{
+				Expected: []action.KeyValue{
+					{Key: []byte("k1"), Value: []byte("v1")},
+					{Key: []byte("k2"), Value: []byte("v2")},
+				},
+			},
+			action.Operations(metrics.OpIterator, metrics.ErrorClassNone, 1),
+			action.Prometheus(
+				`corekv_iterator_items_bucket{le="1"} 0`,
+				`corekv_iterator_items_bucket{le="10"} 1`,
+				`corekv_iterator_items_sum 2`,
+				`corekv_iterator_items_count 1`,
+				`corekv_value_size_bytes_count{op="iterator"} 2`,
+			),
+		},
+	}
+
+	test.Execute(t)
+}
+
+func TestMetrics_Prometheus(t *testing.T) {
+	test := &integration.Test{
+		Actions: []action.Action{
+			action.Metrics(),
+			action.Set([]byte("k1"), []byte("v1")),
+			action.Set([]byte("k2"), make([]byte, 100)),
+			action.GetE([]byte("k3"), corekv.ErrNotFound.Error()),
+			action.Prometheus(
+				`# TYPE corekv_operations_total counter`,
+				`corekv_operations_total{op="get",error="not_found"} 1`,
+				`corekv_operations_total{op="set",error="none"} 2`,
+				`corekv_operation_duration_seconds_count{op="set"} 2`,
+				`corekv_key_size_bytes_bucket{op="set",le="16"} 2`,
+				`corekv_key_size_bytes_sum{op="set"} 4`,
+				`corekv_value_size_bytes_bucket{op="set",le="16"} 1`,
+				`corekv_value_size_bytes_bucket{op="set",le="256"} 2`,
+				`corekv_value_size_bytes_bucket{op="set",le="+Inf"} 2`,
+				`corekv_value_size_bytes_sum{op="set"} 102`,
+			),
+		},
+	}
+
+	test.Execute(t)
+}
+
+func TestMetrics_Txn(t *testing.T) {
+	test := &integration.Test{
+		Actions: []action.Action{
+			action.Metrics(),
+			action.NewTxn(false),
+			action.WithTxn(0, action.Set([]byte("k1"), []byte("v1"))),
+			action.Commit(0),
+			action.NewTxn(true),
+			action.WithTxn(1, action.Get([]byte("k1"), []byte("v1"))),
+			action.Discard(1),
+			action.Operations(metrics.OpSet, metrics.ErrorClassNone, 1),
+			action.Operations(metrics.OpGet, metrics.ErrorClassNone, 1),
+			action.Operations(metrics.OpCommit, metrics.ErrorClassNone, 1),
+			action.Operations(metrics.OpDiscard, metrics.ErrorClassNone, 1),
+		},
+	}
+
+	test.Execute(t)
+}
+
+func TestMetrics_TxnConflict(t *testing.T) {
+	test := &integration.Test{
+		Actions: []action.Action{
+			action.Set([]byte("k1"), []byte("v1")),
+			action.Metrics(),
+			action.NewTxn(false),
+			action.WithTxn(0, action.Get([]byte("k1"), []byte("v1"))),
+			action.WithTxn(0, action.Set([]byte("k2"), []byte("v2"))),
+			action.Set([]byte("k1"), []byte("v3")),
+			action.CommitE(0, "Conflict. Please retry"),
+			action.Operations(metrics.OpCommit, metrics.ErrorClassTxnConflict, 1),
+		},
+	}
+
+	test.Execute(t)
+}
+
+func TestMetrics_DBClosed(t *testing.T) {
+	test := &integration.Test{
+		Actions: []action.Action{
+			action.Metrics(),
+			action.Close(),
+			action.GetE([]byte("k1"), "datastore closed"),
+			action.HasE([]byte("k1"), "datastore closed"),
+			action.Operations(metrics.OpGet, metrics.ErrorClassDBClosed, 1),
+			action.Operations(metrics.OpHas, metrics.ErrorClassDBClosed, 1),
+		},
+	}
+
+	test.Execute(t)
+}
+
+func TestMetrics_Other(t *testing.T) {
+	test := &integration.Test{
+		Actions: []action.Action{
+			action.Metrics(),
+			action.SetE(nil, []byte("v1"), corekv.ErrEmptyKey.Error()),
+			action.Operations(metrics.OpSet, metrics.ErrorClassOther, 1),
+		},
+	}
+
+	test.Execute(t)
+}
+
+func TestMetrics_Batch(t *testing.T) {
+	test := &integration.Test{
+		Actions: []action.Action{
+			action.Metrics(),
+			action.NewBatch(),
+			action.BatchSet(0, []byte("k1"), []byte("v1")),
+			action.BatchDelete(0, []byte("k2")),
+			action.BatchCommit(0),
+			action.Get([]byte("k1"), []byte("v1")),
+			action.Operations(metrics.OpBatch, metrics.ErrorClassNone, 1),
+			action.Operations(metrics.OpSet, metrics.ErrorClassNone, 1),
+			action.Operations(metrics.OpDelete, metrics.ErrorClassNone, 1),
+			action.Operations(metrics.OpCommit, metrics.ErrorClassNone, 1),
+		},
+	}
+
+	test.Execute(t)
+}
+
+func TestMetrics_Capabilities(t *testing.T) {
+	test := &integration.Test{
+		Actions: []action.Action{
+			action.Metrics(),
+			action.Watch([]byte("k")),
+			action.SetIfAbsent([]byte("k1"), []byte("v1"), true),
+			action.CompareAndSwap([]byte("k1"), []byte("v1"), []byte("v2"), true),
+			action.DeleteIfEquals([]byte("k1"), []byte("v1"), false),
+			action.SetWithTTL([]byte("k2"), []byte("v3"), time.Hour),
+			action.GetMany(
+				[][]byte{[]byte("k1"), []byte("k3")},
+				[]corekv.GetResult{{Value: []byte("v2"), Found: true}, {}},
+			),
+			action.HasMany([][]byte{[]byte("k1"), []byte("k3")}, []bool{true, false}),
+			action.DeleteRange([]byte("k1"), []byte("k2")),
+			action.DeletePrefix([]byte("k")),
+			action.Events(
+				0,
+				corekv.Event{Key: []byte("k1"), Value: []byte("v1")},
+				corekv.Event{Key: []byte("k1"), Value: []byte("v2")},
+				corekv.Event{Key: []byte("k2"), Value: []byte("v3")},
+				corekv.Event{Key: []byte("k1"), IsDeleted: true},
+				corekv.Event{Key: []byte("k2"), IsDeleted: true},
+			),
+			action.Operations(metrics.OpWatch, metrics.ErrorClassNone, 1),
+			action.Operations(metrics.OpSetIfAbsent, metrics.ErrorClassNone, 1),
+			action.Operations(metrics.OpCompareAndSwap, metrics.ErrorClassNone, 1),
+			action.Operations(metrics.OpDeleteIfEquals, metrics.ErrorClassNone, 1),
+			action.Operations(metrics.OpSetWithTTL, metrics.ErrorClassNone, 1),
+			action.Operations(metrics.OpGetMany, metrics.ErrorClassNone, 1),
+			action.Operations(metrics.OpHasMany, metrics.ErrorClassNone, 1),
+			action.Operations(metrics.OpDeleteRange, metrics.ErrorClassNone, 1),
+			action.Operations(metrics.OpDeletePrefix, metrics.ErrorClassNone, 1),
+		},
+	}
+
+	test.Execute(t)
+}
+
+func TestMetrics_IteratorPrev(t *testing.T) {
+	test := &integration.Test{
+		Actions: []action.Action{
+			action.Set([]byte("k1"), []byte("v1")),
+			action.Set([]byte("k2"), []byte("v2")),
+			action.Metrics(),
+			&action.Iterator{
+				ChildActions: []action.IteratorAction{
+					action.Next(true),
+					action.Next(true),
+					action.Peek(nil, false),
+					action.Prev(true),
+					action.Key([]byte("k1")),
+				},
+			},
+			action.Operations(metrics.OpIterator, metrics.ErrorClassNone, 1),
+		},
+	}
+
+	test.Execute(t)
+}
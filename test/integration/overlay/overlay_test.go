@@ -0,0 +1,167 @@
+package overlay
+
+import (
+	"testing"
+
+	"github.com/sourcenetwork/corekv"
+	"github.com/sourcenetwork/corekv/test/action"
+	"github.com/sourcenetwork/corekv/test/integration"
+)
+
+func TestOverlay_ReadsFallThrough(t *testing.T) {
+	test := &integration.Test{
+		Actions: []action.Action{
+			action.Set([]byte("k1"), []byte("v1")),
+			action.Overlay(),
+			action.Get([]byte("k1"), []byte("v1")),
+			action.Has([]byte("k1"), true),
+			action.GetE([]byte("k2"), corekv.ErrNotFound.Error()),
+		},
+	}
+
+	test.Execute(t)
+}
+
+func TestOverlay_WritesDoNotTouchBase(t *testing.T) {
+	test := &integration.Test{
+		Actions: []action.Action{
+			action.Set([]byte("k1"), []byte("v1")),
+			action.Set([]byte("k2"), []byte("v2")),
+			action.Overlay(),
+			action.Set([]byte("k1"), []byte("v1.1")),
+			action.Set([]byte("k3"), nil),
+			action.Delete([]byte("k2")),
+			action.Get([]byte("k1"), []byte("v1.1")),
+			action.Get([]byte("k3"), nil),
+			action.GetE([]byte("k2"), corekv.ErrNotFound.Error()),
+			action.Has([]byte("k2"), false),
+			action.WithBase(action.Get([]byte("k1"), []byte("v1"))),
+			action.WithBase(action.Get([]byte("k2"), []byte("v2"))),
+			action.WithBase(action.Has([]byte("k3"), false)),
+		},
+	}
+
+	test.Execute(t)
+}
+
+func TestOverlay_Iterate(t *testing.T) {
+	test := &integration.Test{
+		Actions: []action.Action{
+			action.Set([]byte("k1"), []byte("v1")),
+			action.Set([]byte("k2"), []byte("v2")),
+			action.Set([]byte("k4"), []byte("v4")),
+			action.Overlay(),
+			action.Set([]byte("k1"), []byte("v1.1")),
+			action.Delete([]byte("k2")),
+			action.Set([]byte("k3"), []byte("v3")),
+			action.Delete([]byte("k5")),
+			&action.Iterate{
+				Expected: []action.KeyValue{
+					{Key: []byte("k1"), Value: []byte("v1.1")},
+					// `k2` has been deleted in the overlay and must not be yielded
+					{Key: []byte("k3"), Value: []byte("v3")},
+					{Key: []byte("k4"), Value: []byte("v4")},
+				},
+			},
+		},
+	}
+
+	test.Execute(t)
+}
+
+func TestOverlay_IterateReverseLimit(t *testing.T) {
+	test := &integration.Test{
+		Actions: []action.Action{
+			action.Set([]byte("k1"), []byte("v1")),
+			action.Set([]byte("k2"), []byte("v2")),
+			action.Set([]byte("k3"), []byte("v3")),
+			action.Overlay(),
+			action.Delete([]byte("k3")),
+			action.Delete([]byte("k2")),
+			&action.Iterate{
+				IterOptions: corekv.IterOptions{
+					Reverse: true,
+					Limit:   1,
+				},
+				Expected: []action.KeyValue{
+					{Key: []byte("k1"), Value: []byte("v1")},
+				},
+			},
+		},
+	}
+
+	test.Execute(t)
+}
+
+func TestOverlay_IterateKeysOnlySeek(t *testing.T) {
+	test := &integration.Test{
+		Actions: []action.Action{
+			action.Set([]byte("k1"), []byte("v1")),
+			action.Set([]byte("k2"), []byte("v2")),
+			action.Set([]byte("k4"), []byte("v4")),
+			action.Overlay(),
+			action.Delete([]byte("k2")),
+			action.Set([]byte("k3"), []byte("v3")),
+			&action.Iterator{
+				IterOptions: corekv.IterOptions{
+					KeysOnly: true,
+				},
+				ChildActions: []action.IteratorAction{
+					action.Seek([]byte("k2"), true),
+					action.Key([]byte("k3")),
+					action.Value(nil),
+					action.Next(true),
+					action.Key([]byte("k4")),
+					action.Next(false),
+				},
+			},
+		},
+	}
+
+	test.Execute(t)
+}
+
+func TestOverlay_Flush(t *testing.T) {
+	test := &integration.Test{
+		Actions: []action.Action{
+			action.Set([]byte("k1"), []byte("v1")),
+			action.Set([]byte("k2"), []byte("v2")),
+			action.Overlay(),
+			action.Set([]byte("k1"), []byte("v1.1")),
+			action.Delete([]byte("k2")),
+			action.Set([]byte("k3"), []byte("v3")),
+			action.Flush(),
+			action.WithBase(&action.Iterate{
+				Expected: []action.KeyValue{
+					{Key: []byte("k1"), Value: []byte("v1.1")},
+					{Key: []byte("k3"), Value: []byte("v3")},
+				},
+			}),
+			// The overlay must be empty following the flush, so writing to the base must be
+			// visible through it.
+			action.WithBase(action.Set([]byte("k1"), []byte("v1.2"))),
+			action.Get([]byte("k1"), []byte("v1.2")),
+		},
+	}
+
+	test.Execute(t)
+}
+
+func TestOverlay_Discard(t *testing.T) {
+	test := &integration.Test{
+		Actions: []action.Action{
+			action.Set([]byte("k1"), []byte("v1")),
+			action.Overlay(),
+			action.Set([]byte("k1"), []byte("v1.1")),
+			action.Set([]byte("k2"), []byte("v2")),
+			action.DiscardChanges(),
+			&action.Iterate{
+				Expected: []action.KeyValue{
+					{Key: []byte("k1"), Value: []byte("v1")},
+				},
+			},
+		},
+	}
+
+	test.Execute(t)
+}
@@ -0,0 +1,54 @@
+package integration
+
+import (
+	"testing"
+
+	"github.com/sourcenetwork/corekv"
+	"github.com/sourcenetwork/corekv/test/action"
+)
+
+func TestSetIfAbsent(t *testing.T) {
+	test := &Test{
+		Actions: []action.Action{
+			action.SetIfAbsent([]byte("k1"), []byte("v1"), true),
+			action.Get([]byte("k1"), []byte("v1")),
+		},
+	}
+
+	test.Execute(t)
+}
+
+func TestSetIfAbsent_KeyExists(t *testing.T) {
+	test := &Test{
+		Actions: []action.Action{
+			action.Set([]byte("k1"), []byte("v1")),
+			action.SetIfAbsent([]byte("k1"), []byte("v2"), false),
+			action.Get([]byte("k1"), []byte("v1")),
+		},
+	}
+
+	test.Execute(t)
+}
+
+func TestSetIfAbsent_KeyDeleted(t *testing.T) {
+	test := &Test{
+		Actions: []action.Action{
+			action.Set([]byte("k1"), []byte("v1")),
+			action.Delete([]byte("k1")),
+			action.SetIfAbsent([]byte("k1"), []byte("v2"), true),
+			action.Get([]byte("k1"), []byte("v2")),
+		},
+	}
+
+	test.Execute(t)
+}
+
+func TestSetIfAbsent_EmptyKey_Errors(t *testing.T) {
+	test := &Test{
+		Actions: []action.Action{
+			action.SetIfAbsentE([]byte{}, []byte("v1"), corekv.ErrEmptyKey.Error()),
+		},
+	}
+
+	test.Execute(t)
+}
@@ -0,0 +1,61 @@
+package integration
+
+import (
+	"testing"
+	"time"
+
+	"github.com/sourcenetwork/corekv"
+	"github.com/sourcenetwork/corekv/test/action"
+)
+
+func TestSetWithTTL(t *testing.T) {
+	test := &Test{
+		Actions: []action.Action{
+			action.SetWithTTL([]byte("k1"), []byte("v1"), time.Hour),
+			action.Get([]byte("k1"), []byte("v1")),
+			action.Has([]byte("k1"), true),
+		},
+	}
+
+	test.Execute(t)
+}
+
+func TestSetWithTTL_Expired(t *testing.T) {
+	test := &Test{
+		Actions: []action.Action{
+			action.Set([]byte("k1"), []byte("v1")),
+			// Badger truncates expiry times to the second, so a time-to-live of at least two
+			// seconds is needed for `k2` to be reliably readable until the next action.
+			action.SetWithTTL([]byte("k2"), []byte("v2"), 2*time.Second),
+			action.SetWithTTL([]byte("k3"), []byte("v3"), time.Hour),
+			action.Get([]byte("k2"), []byte("v2")),
+			// Truncation only brings expiry forward, so `k2` must have expired once the
+			// time-to-live has passed.
+			action.Sleep(2 * time.Second),
+			action.GetE([]byte("k2"), corekv.ErrNotFound.Error()),
+			action.Has([]byte("k2"), false),
+			&action.Iterate{
+				Expected: []action.KeyValue{
+					{Key: []byte("k1"), Value: []byte("v1")},
+					// `k2` has expired and must not be yielded
+					{Key: []byte("k3"), Value: []byte("v3")},
+				},
+			},
+		},
+	}
+
+	test.Execute(t)
+}
+
+func TestSetWithTTL_OverwrittenBySet(t *testing.T) {
+	test := &Test{
+		Actions: []action.Action{
+			action.SetWithTTL([]byte("k1"), []byte("v1"), time.Second),
+			action.Set([]byte("k1"), []byte("v2")),
+			action.Sleep(time.Second),
+			action.Get([]byte("k1"), []byte("v2")),
+		},
+	}
+
+	test.Execute(t)
+}
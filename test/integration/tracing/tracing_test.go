@@ -0,0 +1,366 @@
+package tracing
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/sourcenetwork/corekv"
+	"github.com/sourcenetwork/corekv/test/action"
+	"github.com/sourcenetwork/corekv/test/integration"
+	"github.com/sourcenetwork/corekv/tracing"
+)
+
+// errRecorded marks an expected span as having recorded an error.
+var errRecorded = []error{errors.New("recorded")}
+
+func TestTracing_Operations(t *testing.T) {
+	test := &integration.Test{
+		Actions: []action.Action{
+			action.Trace(0),
+			action.Set([]byte("k1"), []byte("v1")),
+			action.Get([]byte("k1"), []byte("v1")),
+			action.GetE([]byte("k2"), corekv.ErrNotFound.Error()),
+			action.Has([]byte("k1"), true),
+			action.Delete([]byte("k1")),
+			action.Spans(
+				tracing.RecordedSpan{
+					Name:       tracing.SpanSet,
+					Parent:     -1,
+					Attributes: []tracing.Attribute{tracing.String(tracing.AttrKeyPrefix, "k1")},
+					Ended:      true,
+				},
+				tracing.RecordedSpan{
+					Name:       tracing.SpanGet,
+					Parent:     -1,
+					Attributes: []tracing.Attribute{tracing.String(tracing.AttrKeyPrefix, "k1")},
+					Ended:      true,
+				},
+				tracing.RecordedSpan{
+					Name:       tracing.SpanGet,
+					Parent:     -1,
+					Attributes: []tracing.Attribute{tracing.String(tracing.AttrKeyPrefix, "k2")},
+					Errors:     errRecorded,
+					Ended:      true,
+				},
+				tracing.RecordedSpan{
+					Name:       tracing.SpanHas,
+					Parent:     -1,
+					Attributes: []tracing.Attribute{tracing.String(tracing.AttrKeyPrefix, "k1")},
+					Ended:      true,
+				},
+				tracing.RecordedSpan{
+					Name:       tracing.SpanDelete,
+					Parent:     -1,
+					Attributes: []tracing.Attribute{tracing.String(tracing.AttrKeyPrefix, "k1")},
+					Ended:      true,
+				},
+			),
+		},
+	}
+
+	test.Execute(t)
+}
+
+func TestTracing_KeyPrefixLength(t *testing.T) {
+	test := &integration.Test{
+		Actions: []action.Action{
+			action.Trace(3),
+			action.Set([]byte("users/1"), []byte("v1")),
+			action.Spans(
+				tracing.RecordedSpan{
+					Name:       tracing.SpanSet,
+					Parent:     -1,
+					Attributes: []tracing.Attribute{tracing.String(tracing.AttrKeyPrefix, "use")},
+					Ended:      true,
+				},
+			),
+		},
+	}
+
+	test.Execute(t)
+}
+
+func TestTracing_Iterator(t *testing.T) {
+	test := &integration.Test{
+		Actions: []action.Action{
+			action.Set([]byte("k1"), []byte("v1")),
+			action.Trace(0),
+			&action.Iterate{
+				IterOptions: corekv.IterOptions{
+					Prefix: []byte("k"),
+				},
+				Expected: []action.KeyValue{
+					{Key: []byte("k1"), Value: []byte("v1")},
+				},
+			},
+			action.Spans(
+				tracing.RecordedSpan{
+					Name:   tracing.SpanIterator,
+					Parent: -1,
+					Attributes: []tracing.Attribute{
+						tracing.String(tracing.AttrIterPrefix, "k"),
+						tracing.Bool(tracing.AttrIterReverse, false),
+					},
+					Ended: true,
+				},
+				tracing.RecordedSpan{
+					Name:   tracing.SpanIteratorNext,
+					Parent: 0,
+					Attributes: []tracing.Attribute{
+						tracing.Bool(tracing.AttrHasNext, true),
+						tracing.String(tracing.AttrKeyPrefix, "k1"),
+					},
+					Ended: true,
+				},
+				tracing.RecordedSpan{
+					Name:   tracing.SpanIteratorNext,
+					Parent: 0,
+					Attributes: []tracing.Attribute{
+						tracing.Bool(tracing.AttrHasNext, false),
+					},
+					Ended: true,
+				},
+			),
+		},
+	}
+
+	test.Execute(t)
+}
+
+func TestTracing_Txn(t *testing.T) {
+	txnAttrs := []tracing.Attribute{
+		tracing.Bool(tracing.AttrTxn, true),
+		tracing.Bool(tracing.AttrTxnReadOnly, false),
+	}
+
+	test := &integration.Test{
+		Actions: []action.Action{
+			action.Trace(0),
+			action.NewTxn(false),
+			action.WithTxn(0, action.Set([]byte("k1"), []byte("v1"))),
+			action.Commit(0),
+			action.Spans(
+				tracing.RecordedSpan{
+					Name:   tracing.SpanSet,
+					Parent: -1,
+					Attributes: append(
+						[]tracing.Attribute{tracing.String(tracing.AttrKeyPrefix, "k1")},
+						txnAttrs...,
+					),
+					Ended: true,
+				},
+				tracing.RecordedSpan{
+					Name:       tracing.SpanCommit,
+					Parent:     -1,
+					Attributes: txnAttrs,
+					Ended:      true,
+				},
+			),
+		},
+	}
+
+	test.Execute(t)
+}
+
+func TestTracing_Namespace(t *testing.T) {
+	test := &integration.Test{
+		Actions: []action.Action{
+			action.Namespace([]byte("ns/")),
+			action.Trace(0),
+			action.Set([]byte("k1"), []byte("v1")),
+			action.Spans(
+				tracing.RecordedSpan{
+					Name:       tracing.SpanSet,
+					Parent:     -1,
+					Attributes: []tracing.Attribute{tracing.String(tracing.AttrKeyPrefix, "k1")},
+					Ended:      true,
+				},
+			),
+		},
+	}
+
+	test.Execute(t)
+}
+
+func TestTracing_Batch(t *testing.T) {
+	batchAttrs := []tracing.Attribute{tracing.Bool(tracing.AttrBatch, true)}
+
+	test := &integration.Test{
+		Actions: []action.Action{
+			action.Trace(0),
+			action.NewBatch(),
+			action.BatchSet(0, []byte("k1"), []byte("v1")),
+			action.BatchCommit(0),
+			action.Spans(
+				tracing.RecordedSpan{
+					Name:   tracing.SpanBatch,
+					Parent: -1,
+					Ended:  true,
+				},
+				tracing.RecordedSpan{
+					Name:   tracing.SpanSet,
+					Parent: -1,
+					Attributes: append(
+						[]tracing.Attribute{tracing.String(tracing.AttrKeyPrefix, "k1")},
+						batchAttrs...,
+					),
+					Ended: true,
+				},
+				tracing.RecordedSpan{
+					Name:       tracing.SpanCommit,
+					Parent:     -1,
+					Attributes: batchAttrs,
+					Ended:      true,
+				},
+			),
+		},
+	}
+
+	test.Execute(t)
+}
+
+func TestTracing_Capabilities_Namespace(t *testing.T) {
+	test := &integration.Test{
+		Actions: []action.Action{
+			action.Namespace([]byte("ns/")),
+			action.Trace(0),
+			action.Watch([]byte("k")),
+			action.SetIfAbsent([]byte("k1"), []byte("v1"), true),
+			action.CompareAndSwap([]byte("k1"), []byte("v1"), []byte("v2"), true),
+			action.DeleteIfEquals([]byte("k1"), []byte("v1"), false),
+			action.SetWithTTL([]byte("k2"), []byte("v3"), time.Hour),
+			action.GetMany(
+				[][]byte{[]byte("k1"), []byte("k3")},
+				[]corekv.GetResult{{Value: []byte("v2"), Found: true}, {}},
+			),
+			action.HasMany([][]byte{[]byte("k1"), []byte("k3")}, []bool{true, false}),
+			action.DeleteRange([]byte("k1"), []byte("k2")),
+			action.DeletePrefix([]byte("k")),
+			action.Events(
+				0,
+				corekv.Event{Key: []byte("k1"), Value: []byte("v1")},
+				corekv.Event{Key: []byte("k1"), Value: []byte("v2")},
+				corekv.Event{Key: []byte("k2"), Value: []byte("v3")},
+				corekv.Event{Key: []byte("k1"), IsDeleted: true},
+				corekv.Event{Key: []byte("k2"), IsDeleted: true},
+			),
+			action.Spans(
+				tracing.RecordedSpan{
+					Name:       tracing.SpanWatch,
+					Parent:     -1,
+					Attributes: []tracing.Attribute{tracing.String(tracing.AttrKeyPrefix, "k")},
+					Ended:      true,
+				},
+				tracing.RecordedSpan{
+					Name:       tracing.SpanSetIfAbsent,
+					Parent:     -1,
+					Attributes: []tracing.Attribute{tracing.String(tracing.AttrKeyPrefix, "k1")},
+					Ended:      true,
+				},
+				tracing.RecordedSpan{
+					Name:       tracing.SpanCompareAndSwap,
+					Parent:     -1,
+					Attributes: []tracing.Attribute{tracing.String(tracing.AttrKeyPrefix, "k1")},
+					Ended:      true,
+				},
+				tracing.RecordedSpan{
+					Name:       tracing.SpanDeleteIfEquals,
+					Parent:     -1,
+					Attributes: []tracing.Attribute{tracing.String(tracing.AttrKeyPrefix, "k1")},
+					Ended:      true,
+				},
+				tracing.RecordedSpan{
+					Name:       tracing.SpanSetWithTTL,
+					Parent:     -1,
+					Attributes: []tracing.Attribute{tracing.String(tracing.AttrKeyPrefix, "k2")},
+					Ended:      true,
+				},
+				tracing.RecordedSpan{
+					Name:       tracing.SpanGetMany,
+					Parent:     -1,
+					Attributes: []tracing.Attribute{tracing.Int(tracing.AttrKeyCount, 2)},
+					Ended:      true,
+				},
+				tracing.RecordedSpan{
+					Name:       tracing.SpanHasMany,
+					Parent:     -1,
+					Attributes: []tracing.Attribute{tracing.Int(tracing.AttrKeyCount, 2)},
+					Ended:      true,
+				},
+				tracing.RecordedSpan{
+					Name:   tracing.SpanDeleteRange,
+					Parent: -1,
+					Attributes: []tracing.Attribute{
+						tracing.String(tracing.AttrRangeStart, "k1"),
+						tracing.String(tracing.AttrRangeEnd, "k2"),
+					},
+					Ended: true,
+				},
+				tracing.RecordedSpan{
+					Name:       tracing.SpanDeletePrefix,
+					Parent:     -1,
+					Attributes: []tracing.Attribute{tracing.String(tracing.AttrKeyPrefix, "k")},
+					Ended:      true,
+				},
+			),
+		},
+	}
+
+	test.Execute(t)
+}
+
+func TestTracing_IteratorPrev(t *testing.T) {
+	test := &integration.Test{
+		Actions: []action.Action{
+			action.Set([]byte("k1"), []byte("v1")),
+			action.Trace(0),
+			&action.Iterator{
+				ChildActions: []action.IteratorAction{
+					action.Peek([]byte("k1"), true),
+					action.Next(true),
+					action.Prev(false),
+				},
+			},
+			action.Spans(
+				tracing.RecordedSpan{
+					Name:   tracing.SpanIterator,
+					Parent: -1,
+					Attributes: []tracing.Attribute{
+						tracing.String(tracing.AttrIterPrefix, ""),
+						tracing.Bool(tracing.AttrIterReverse, false),
+					},
+					Ended: true,
+				},
+				tracing.RecordedSpan{
+					Name:   tracing.SpanIteratorPeek,
+					Parent: 0,
+					Attributes: []tracing.Attribute{
+						tracing.Bool(tracing.AttrHasNext, true),
+						tracing.String(tracing.AttrKeyPrefix, "k1"),
+					},
+					Ended: true,
+				},
+				tracing.RecordedSpan{
+					Name:   tracing.SpanIteratorNext,
+					Parent: 0,
+					Attributes: []tracing.Attribute{
+						tracing.Bool(tracing.AttrHasNext, true),
+						tracing.String(tracing.AttrKeyPrefix, "k1"),
+					},
+					Ended: true,
+				},
+				tracing.RecordedSpan{
+					Name:   tracing.SpanIteratorPrev,
+					Parent: 0,
+					Attributes: []tracing.Attribute{
+						tracing.Bool(tracing.AttrHasNext, false),
+					},
+					Ended: true,
+				},
+			),
+		},
+	}
+
+	test.Execute(t)
+}
@@ -0,0 +1,239 @@
+package txn
+
+import (
+	"testing"
+
+	"github.com/sourcenetwork/corekv"
+	"github.com/sourcenetwork/corekv/test/action"
+	"github.com/sourcenetwork/corekv/test/integration"
+	"github.com/sourcenetwork/corekv/test/state"
+)
+
+func TestTxnCommit(t *testing.T) {
+	test := &integration.Test{
+		Actions: []action.Action{
+			action.NewTxn(false),
+			action.WithTxn(0, action.Set([]byte("k1"), []byte("v1"))),
+			action.WithTxn(0, action.Get([]byte("k1"), []byte("v1"))),
+			action.GetE([]byte("k1"), corekv.ErrNotFound.Error()),
+			action.Commit(0),
+			action.Get([]byte("k1"), []byte("v1")),
+		},
+	}
+
+	test.Execute(t)
+}
+
+func TestTxnCommit_Delete(t *testing.T) {
+	test := &integration.Test{
+		Actions: []action.Action{
+			action.Set([]byte("k1"), []byte("v1")),
+			action.NewTxn(false),
+			action.WithTxn(0, action.Delete([]byte("k1"))),
+			action.WithTxn(0, action.Has([]byte("k1"), false)),
+			action.Has([]byte("k1"), true),
+			action.Commit(0),
+			action.Has([]byte("k1"), false),
+		},
+	}
+
+	test.Execute(t)
+}
+
+func TestTxnCommit_MemoryStoreReadOnly_Errors(t *testing.T) {
+	test := &integration.Test{
+		SupportedStoreTypes: []state.StoreType{
+			state.MemoryStoreType,
+		},
+		Actions: []action.Action{
+			action.NewTxn(true),
+			action.WithTxn(0, action.SetE([]byte("k1"), []byte("v1"), "read only transaction")),
+			action.Commit(0),
+		},
+	}
+
+	test.Execute(t)
+}
+
+func TestTxnCommit_BadgerStoreReadOnly_Errors(t *testing.T) {
+	test := &integration.Test{
+		SupportedStoreTypes: []state.StoreType{
+			state.BadgerStoreType,
+		},
+		Actions: []action.Action{
+			action.NewTxn(true),
+			action.WithTxn(0, action.SetE([]byte("k1"), []byte("v1"), "No sets or deletes are allowed in a read-only transaction")),
+			action.Commit(0),
+		},
+	}
+
+	test.Execute(t)
+}
+
+func TestTxnCommit_Conflict_Errors(t *testing.T) {
+	test := &integration.Test{
+		Actions: []action.Action{
+			action.Set([]byte("k1"), []byte("v1")),
+			action.NewTxn(false),
+			action.NewTxn(false),
+			action.WithTxn(0, action.Get([]byte("k1"), []byte("v1"))),
+			action.WithTxn(1, action.Get([]byte("k1"), []byte("v1"))),
+			action.WithTxn(0, action.Set([]byte("k1"), []byte("v2"))),
+			action.WithTxn(1, action.Set([]byte("k1"), []byte("v3"))),
+			action.Commit(0),
+			action.CommitE(1, "Conflict"),
+			action.Get([]byte("k1"), []byte("v2")),
+		},
+	}
+
+	test.Execute(t)
+}
+
+func TestTxnCommit_ChangesAfterCreation_NotVisible(t *testing.T) {
+	test := &integration.Test{
+		Actions: []action.Action{
+			action.Set([]byte("k1"), []byte("v1")),
+			action.NewTxn(true),
+			action.Set([]byte("k1"), []byte("v2")),
+			action.WithTxn(0, action.Get([]byte("k1"), []byte("v1"))),
+			action.Get([]byte("k1"), []byte("v2")),
+		},
+	}
+
+	test.Execute(t)
+}
+
+func TestTxnCommit_BlindWrites_NoConflict(t *testing.T) {
+	test := &integration.Test{
+		Actions: []action.Action{
+			action.Set([]byte("k1"), []byte("v1")),
+			action.NewTxn(false),
+			action.NewTxn(false),
+			action.WithTxn(0, action.Set([]byte("k1"), []byte("v2"))),
+			action.WithTxn(1, action.Set([]byte("k1"), []byte("v3"))),
+			action.Commit(0),
+			action.Commit(1),
+			action.Get([]byte("k1"), []byte("v3")),
+		},
+	}
+
+	test.Execute(t)
+}
+
+func TestTxnCommit_ReadUnchangedKey_NoConflict(t *testing.T) {
+	test := &integration.Test{
+		Actions: []action.Action{
+			action.Set([]byte("k1"), []byte("v1")),
+			action.NewTxn(false),
+			action.WithTxn(0, action.Get([]byte("k1"), []byte("v1"))),
+			action.WithTxn(0, action.Set([]byte("k2"), []byte("v2"))),
+			action.Set([]byte("k3"), []byte("v3")),
+			action.Commit(0),
+			action.Get([]byte("k2"), []byte("v2")),
+		},
+	}
+
+	test.Execute(t)
+}
+
+func TestTxnCommit_DeleteMissingKey_NoConflict(t *testing.T) {
+	test := &integration.Test{
+		Actions: []action.Action{
+			action.NewTxn(false),
+			action.WithTxn(0, action.Delete([]byte("k1"))),
+			action.Set([]byte("k1"), []byte("v1")),
+			action.Commit(0),
+		},
+	}
+
+	test.Execute(t)
+}
+
+func TestTxnCommit_IteratedKeyChanged_Errors(t *testing.T) {
+	test := &integration.Test{
+		Actions: []action.Action{
+			action.Set([]byte("k1"), []byte("v1")),
+			action.NewTxn(false),
+			action.WithTxn(0, &action.Iterate{
+				Expected: []action.KeyValue{
+					{Key: []byte("k1"), Value: []byte("v1")},
+				},
+			}),
+			action.WithTxn(0, action.Set([]byte("k2"), []byte("v2"))),
+			action.Set([]byte("k1"), []byte("v3")),
+			action.CommitE(0, "Conflict"),
+		},
+	}
+
+	test.Execute(t)
+}
+
+func TestTxnCommit_MemoryStoreKeyAddedToIteratedRange_Errors(t *testing.T) {
+	test := &integration.Test{
+		SupportedStoreTypes: []state.StoreType{
+			// Badger does not detect keys added to ranges read by a transaction
+			state.MemoryStoreType,
+		},
+		Actions: []action.Action{
+			action.Set([]byte("k1"), []byte("v1")),
+			action.NewTxn(false),
+			action.WithTxn(0, &action.Iterate{
+				IterOptions: corekv.IterOptions{
+					Prefix: []byte("k"),
+				},
+				Expected: []action.KeyValue{
+					{Key: []byte("k1"), Value: []byte("v1")},
+				},
+			}),
+			action.WithTxn(0, action.Set([]byte("x"), []byte("v2"))),
+			action.Set([]byte("k2"), []byte("v2")),
+			action.CommitE(0, `Conflict. Please retry: key "`),
+		},
+	}
+
+	test.Execute(t)
+}
+
+func TestTxnCommit_MemoryStoreKeyAddedOutsideIteratedRange_NoConflict(t *testing.T) {
+	test := &integration.Test{
+		SupportedStoreTypes: []state.StoreType{
+			state.MemoryStoreType,
+		},
+		Actions: []action.Action{
+			action.Set([]byte("k1"), []byte("v1")),
+			action.NewTxn(false),
+			action.WithTxn(0, &action.Iterate{
+				IterOptions: corekv.IterOptions{
+					Prefix: []byte("k"),
+				},
+				Expected: []action.KeyValue{
+					{Key: []byte("k1"), Value: []byte("v1")},
+				},
+			}),
+			action.WithTxn(0, action.Set([]byte("x"), []byte("v2"))),
+			action.Set([]byte("y"), []byte("v2")),
+			action.Commit(0),
+		},
+	}
+
+	test.Execute(t)
+}
+
+func TestTxnCommit_MemoryStoreConflict_ReportsKey(t *testing.T) {
+	test := &integration.Test{
+		SupportedStoreTypes: []state.StoreType{
+			state.MemoryStoreType,
+		},
+		Actions: []action.Action{
+			action.NewTxn(false),
+			action.WithTxn(0, action.Has([]byte("k1"), false)),
+			action.WithTxn(0, action.Has([]byte("k2"), false)),
+			action.WithTxn(0, action.Set([]byte("k3"), []byte("v3"))),
+			action.Set([]byte("k2"), []byte("v2")),
+			// The key is suffixed, as it may also be prefixed by a namespace
+			action.CommitE(0, `k2"`),
+		},
+	}
+
+	test.Execute(t)
+}
@@ -0,0 +1,35 @@
+package txn
+
+import (
+	"testing"
+
+	"github.com/sourcenetwork/corekv"
+	"github.com/sourcenetwork/corekv/test/action"
+	"github.com/sourcenetwork/corekv/test/integration"
+)
+
+func TestTxnDiscard(t *testing.T) {
+	test := &integration.Test{
+		Actions: []action.Action{
+			action.NewTxn(false),
+			action.WithTxn(0, action.Set([]byte("k1"), []byte("v1"))),
+			action.Discard(0),
+			action.GetE([]byte("k1"), corekv.ErrNotFound.Error()),
+		},
+	}
+
+	test.Execute(t)
+}
+
+func TestTxnDiscard_CommitAfterDiscard_Errors(t *testing.T) {
+	test := &integration.Test{
+		Actions: []action.Action{
+			action.NewTxn(false),
+			action.WithTxn(0, action.Set([]byte("k1"), []byte("v1"))),
+			action.Discard(0),
+			action.CommitE(0, "discarded"),
+		},
+	}
+
+	test.Execute(t)
+}
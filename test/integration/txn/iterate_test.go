@@ -0,0 +1,220 @@
+package txn
+
+import (
+	"testing"
+
+	"github.com/sourcenetwork/corekv"
+	"github.com/sourcenetwork/corekv/test/action"
+	"github.com/sourcenetwork/corekv/test/integration"
+)
+
+func TestTxnIterate(t *testing.T) {
+	test := &integration.Test{
+		Actions: []action.Action{
+			action.Set([]byte("k1"), []byte("v1")),
+			action.Set([]byte("k3"), []byte("v3")),
+			action.Set([]byte("k5"), []byte("v5")),
+			action.NewTxn(false),
+			action.WithTxn(0, action.Set([]byte("k1"), []byte("v1.1"))),
+			action.WithTxn(0, action.Set([]byte("k2"), []byte("v2"))),
+			action.WithTxn(0, action.Delete([]byte("k3"))),
+			action.WithTxn(0, action.Set([]byte("k6"), []byte("v6"))),
+			action.WithTxn(0, &action.Iterate{
+				Expected: []action.KeyValue{
+					{Key: []byte("k1"), Value: []byte("v1.1")},
+					{Key: []byte("k2"), Value: []byte("v2")},
+					// `k3` has been deleted within the transaction and must not be yielded
+					{Key: []byte("k5"), Value: []byte("v5")},
+					{Key: []byte("k6"), Value: []byte("v6")},
+				},
+			}),
+		},
+	}
+
+	test.Execute(t)
+}
+
+func TestTxnIterate_Reverse(t *testing.T) {
+	test := &integration.Test{
+		Actions: []action.Action{
+			action.Set([]byte("k1"), []byte("v1")),
+			action.Set([]byte("k3"), []byte("v3")),
+			action.Set([]byte("k5"), []byte("v5")),
+			action.NewTxn(false),
+			action.WithTxn(0, action.Set([]byte("k1"), []byte("v1.1"))),
+			action.WithTxn(0, action.Set([]byte("k2"), []byte("v2"))),
+			action.WithTxn(0, action.Delete([]byte("k5"))),
+			action.WithTxn(0, &action.Iterate{
+				IterOptions: corekv.IterOptions{
+					Reverse: true,
+				},
+				Expected: []action.KeyValue{
+					// `k5` has been deleted within the transaction and must not be yielded
+					{Key: []byte("k3"), Value: []byte("v3")},
+					{Key: []byte("k2"), Value: []byte("v2")},
+					{Key: []byte("k1"), Value: []byte("v1.1")},
+				},
+			}),
+		},
+	}
+
+	test.Execute(t)
+}
+
+func TestTxnIterate_Prefix(t *testing.T) {
+	test := &integration.Test{
+		Actions: []action.Action{
+			action.Set([]byte("a1"), []byte("va1")),
+			action.Set([]byte("k1"), []byte("v1")),
+			action.NewTxn(false),
+			action.WithTxn(0, action.Set([]byte("a2"), []byte("va2"))),
+			action.WithTxn(0, action.Set([]byte("k2"), []byte("v2"))),
+			action.WithTxn(0, &action.Iterate{
+				IterOptions: corekv.IterOptions{
+					Prefix: []byte("k"),
+				},
+				Expected: []action.KeyValue{
+					{Key: []byte("k1"), Value: []byte("v1")},
+					{Key: []byte("k2"), Value: []byte("v2")},
+				},
+			}),
+		},
+	}
+
+	test.Execute(t)
+}
+
+func TestTxnIterate_ChangesAfterCreation_NotYielded(t *testing.T) {
+	test := &integration.Test{
+		Actions: []action.Action{
+			action.Set([]byte("k1"), []byte("v1")),
+			action.NewTxn(false),
+			action.Set([]byte("k1"), []byte("v1.1")),
+			action.Set([]byte("k2"), []byte("v2")),
+			action.WithTxn(0, &action.Iterate{
+				Expected: []action.KeyValue{
+					{Key: []byte("k1"), Value: []byte("v1")},
+				},
+			}),
+		},
+	}
+
+	test.Execute(t)
+}
+
+func TestTxnIterate_SeekValue(t *testing.T) {
+	test := &integration.Test{
+		Actions: []action.Action{
+			action.Set([]byte("k1"), []byte("v1")),
+			action.Set([]byte("k3"), []byte("v3")),
+			action.NewTxn(false),
+			action.WithTxn(0, action.Set([]byte("k2"), []byte("v2"))),
+			action.WithTxn(0, &action.Iterator{
+				ChildActions: []action.IteratorAction{
+					action.Seek([]byte("k2"), true),
+					action.Value([]byte("v2")),
+					action.Next(true),
+					action.Value([]byte("v3")),
+					action.Next(false),
+				},
+			}),
+		},
+	}
+
+	test.Execute(t)
+}
+
+func TestTxnIterate_KeysOnly(t *testing.T) {
+	test := &integration.Test{
+		Actions: []action.Action{
+			action.Set([]byte("k1"), []byte("v1")),
+			action.Set([]byte("k3"), []byte("v3")),
+			action.NewTxn(false),
+			action.WithTxn(0, action.Set([]byte("k2"), []byte("v2"))),
+			action.WithTxn(0, action.Delete([]byte("k3"))),
+			action.WithTxn(0, &action.Iterate{
+				IterOptions: corekv.IterOptions{
+					KeysOnly: true,
+				},
+				Expected: []action.KeyValue{
+					{Key: []byte("k1"), Value: nil},
+					{Key: []byte("k2"), Value: nil},
+				},
+			}),
+		},
+	}
+
+	test.Execute(t)
+}
+
+func TestTxnIterate_Prev(t *testing.T) {
+	test := &integration.Test{
+		Actions: []action.Action{
+			action.Set([]byte("k1"), []byte("v1")),
+			action.Set([]byte("k3"), []byte("v3")),
+			action.Set([]byte("k5"), []byte("v5")),
+			action.NewTxn(false),
+			action.WithTxn(0, action.Set([]byte("k2"), []byte("v2"))),
+			action.WithTxn(0, action.Delete([]byte("k3"))),
+			action.WithTxn(0, &action.Iterator{
+				ChildActions: []action.IteratorAction{
+					action.Seek([]byte("k5"), true),
+					action.Peek(nil, false),
+					// `k3` has been deleted within the transaction and must not be yielded
+					action.Prev(true),
+					action.Key([]byte("k2")),
+					action.Value([]byte("v2")),
+					action.Peek([]byte("k5"), true),
+					action.Prev(true),
+					action.Key([]byte("k1")),
+					action.Prev(false),
+				},
+			}),
+		},
+	}
+
+	test.Execute(t)
+}
+
+func TestTxnIterate_SetFollowingCommittedKey(t *testing.T) {
+	test := &integration.Test{
+		Actions: []action.Action{
+			action.Set([]byte("k1"), []byte("v1")),
+			action.Set([]byte("k2"), []byte("v2")),
+			action.NewTxn(false),
+			action.WithTxn(0, action.Set([]byte("k2"), []byte("v2.1"))),
+			action.WithTxn(0, &action.Iterate{
+				Expected: []action.KeyValue{
+					{Key: []byte("k1"), Value: []byte("v1")},
+					{Key: []byte("k2"), Value: []byte("v2.1")},
+				},
+			}),
+		},
+	}
+
+	test.Execute(t)
+}
+
+func TestTxnIterate_LimitResumeToken(t *testing.T) {
+	test := &integration.Test{
+		Actions: []action.Action{
+			action.Set([]byte("k1"), []byte("v1")),
+			action.Set([]byte("k3"), []byte("v3")),
+			action.NewTxn(false),
+			action.WithTxn(0, action.Set([]byte("k2"), []byte("v2"))),
+			action.WithTxn(0, action.Set([]byte("k4"), []byte("v4"))),
+			action.WithTxn(0, &action.Iterate{
+				IterOptions: corekv.IterOptions{
+					Limit:       2,
+					ResumeToken: corekv.NewResumeToken([]byte("k1"), false),
+				},
+				Expected: []action.KeyValue{
+					{Key: []byte("k2"), Value: []byte("v2")},
+					{Key: []byte("k3"), Value: []byte("v3")},
+				},
+			}),
+		},
+	}
+
+	test.Execute(t)
+}
@@ -0,0 +1,48 @@
+package txn
+
+import (
+	"testing"
+
+	"github.com/sourcenetwork/corekv"
+	"github.com/sourcenetwork/corekv/test/action"
+	"github.com/sourcenetwork/corekv/test/integration"
+)
+
+func TestTxnNamespace(t *testing.T) {
+	test := &integration.Test{
+		Actions: []action.Action{
+			action.Set([]byte("k1"), []byte("v1")),
+			action.Namespace([]byte("ns")),
+			action.NewTxn(false),
+			action.WithTxn(0, action.GetE([]byte("k1"), corekv.ErrNotFound.Error())),
+			action.WithTxn(0, action.Set([]byte("k2"), []byte("v2"))),
+			action.Commit(0),
+			action.Get([]byte("k2"), []byte("v2")),
+		},
+	}
+
+	test.Execute(t)
+}
+
+func TestTxnNamespace_Iterate(t *testing.T) {
+	test := &integration.Test{
+		Actions: []action.Action{
+			action.Set([]byte("ns"), []byte("namespace exact match")),
+			action.Set([]byte("nsk1"), []byte("v1")),
+			action.Namespace([]byte("ns")),
+			action.NewTxn(false),
+			action.WithTxn(0, action.Set([]byte("k2"), []byte("v2"))),
+			action.WithTxn(0, &action.Iterate{
+				IterOptions: corekv.IterOptions{
+					Prefix: []byte("k"),
+				},
+				Expected: []action.KeyValue{
+					{Key: []byte("k1"), Value: []byte("v1")},
+					{Key: []byte("k2"), Value: []byte("v2")},
+				},
+			}),
+		},
+	}
+
+	test.Execute(t)
+}
@@ -0,0 +1,146 @@
+package txn
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/sourcenetwork/corekv"
+	"github.com/sourcenetwork/corekv/test/action"
+	"github.com/sourcenetwork/corekv/test/integration"
+	"github.com/sourcenetwork/corekv/test/state"
+)
+
+func TestTxnRunInTxn(t *testing.T) {
+	test := &integration.Test{
+		Actions: []action.Action{
+			action.RunInTxn(
+				corekv.RetryOptions{},
+				1,
+				func(s *state.State, txn corekv.Txn, attempt int) error {
+					return txn.Set(s.Ctx, []byte("k1"), []byte("v1"))
+				},
+			),
+			action.Get([]byte("k1"), []byte("v1")),
+		},
+	}
+
+	test.Execute(t)
+}
+
+func TestTxnRunInTxn_Conflict_Retries(t *testing.T) {
+	test := &integration.Test{
+		Actions: []action.Action{
+			action.Set([]byte("k1"), []byte("v1")),
+			action.RunInTxn(
+				corekv.RetryOptions{},
+				2,
+				func(s *state.State, txn corekv.Txn, attempt int) error {
+					value, err := txn.Get(s.Ctx, []byte("k1"))
+					if err != nil {
+						return err
+					}
+					if attempt == 1 {
+						// Change the read key outside of the txn so that the commit conflicts
+						err = s.Store.Set(s.Ctx, []byte("k1"), []byte("v2"))
+						if err != nil {
+							return err
+						}
+					}
+					return txn.Set(s.Ctx, []byte("k2"), value)
+				},
+			),
+			action.Get([]byte("k2"), []byte("v2")),
+		},
+	}
+
+	test.Execute(t)
+}
+
+func TestTxnRunInTxn_ConflictOnEveryAttempt_Errors(t *testing.T) {
+	test := &integration.Test{
+		Actions: []action.Action{
+			action.Set([]byte("k1"), []byte("v1")),
+			action.RunInTxnE(
+				corekv.RetryOptions{MaxAttempts: 3},
+				3,
+				func(s *state.State, txn corekv.Txn, attempt int) error {
+					_, err := txn.Get(s.Ctx, []byte("k1"))
+					if err != nil {
+						return err
+					}
+					err = s.Store.Set(s.Ctx, []byte("k1"), []byte("v2"))
+					if err != nil {
+						return err
+					}
+					return txn.Set(s.Ctx, []byte("k2"), []byte("v3"))
+				},
+				"Conflict. Please retry",
+			),
+			action.Has([]byte("k2"), false),
+		},
+	}
+
+	test.Execute(t)
+}
+
+func TestTxnRunInTxn_FnReturnsConflict_Retries(t *testing.T) {
+	test := &integration.Test{
+		Actions: []action.Action{
+			action.RunInTxnE(
+				corekv.RetryOptions{MaxAttempts: 4},
+				4,
+				func(s *state.State, txn corekv.Txn, attempt int) error {
+					err := txn.Set(s.Ctx, []byte("k1"), []byte("v1"))
+					if err != nil {
+						return err
+					}
+					return corekv.ErrTxnConflict
+				},
+				corekv.ErrTxnConflict.Error(),
+			),
+			action.Has([]byte("k1"), false),
+		},
+	}
+
+	test.Execute(t)
+}
+
+func TestTxnRunInTxn_FnErrors_DiscardsWithoutRetry(t *testing.T) {
+	test := &integration.Test{
+		Actions: []action.Action{
+			action.RunInTxnE(
+				corekv.RetryOptions{},
+				1,
+				func(s *state.State, txn corekv.Txn, attempt int) error {
+					err := txn.Set(s.Ctx, []byte("k1"), []byte("v1"))
+					if err != nil {
+						return err
+					}
+					return errors.New("fn failed")
+				},
+				"fn failed",
+			),
+			action.Has([]byte("k1"), false),
+		},
+	}
+
+	test.Execute(t)
+}
+
+func TestTxnRunInTxn_ContextCancelledDuringBackoff_Errors(t *testing.T) {
+	test := &integration.Test{
+		Actions: []action.Action{
+			action.RunInTxnE(
+				corekv.RetryOptions{},
+				1,
+				func(s *state.State, txn corekv.Txn, attempt int) error {
+					s.CtxCancel()
+					return corekv.ErrTxnConflict
+				},
+				"context canceled",
+			),
+		},
+	}
+
+	test.Execute(t)
+}
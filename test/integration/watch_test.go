@@ -0,0 +1,163 @@
+package integration
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/sourcenetwork/corekv"
+	"github.com/sourcenetwork/corekv/test/action"
+)
+
+func TestWatch(t *testing.T) {
+	test := &Test{
+		Actions: []action.Action{
+			action.Watch(nil),
+			action.Set([]byte("k1"), []byte("v1")),
+			action.Set([]byte("k2"), []byte("v2")),
+			action.Delete([]byte("k1")),
+			action.Events(
+				0,
+				corekv.Event{Key: []byte("k1"), Value: []byte("v1")},
+				corekv.Event{Key: []byte("k2"), Value: []byte("v2")},
+				corekv.Event{Key: []byte("k1"), IsDeleted: true},
+			),
+		},
+	}
+
+	test.Execute(t)
+}
+
+func TestWatch_Prefix(t *testing.T) {
+	test := &Test{
+		Actions: []action.Action{
+			action.Watch([]byte("k")),
+			action.Set([]byte("a1"), []byte("va1")),
+			action.Set([]byte("k1"), []byte("v1")),
+			action.Set([]byte("l1"), []byte("vl1")),
+			action.Set([]byte("k2"), []byte("v2")),
+			action.Events(
+				0,
+				corekv.Event{Key: []byte("k1"), Value: []byte("v1")},
+				corekv.Event{Key: []byte("k2"), Value: []byte("v2")},
+			),
+		},
+	}
+
+	test.Execute(t)
+}
+
+func TestWatch_DeletePrefix(t *testing.T) {
+	test := &Test{
+		Actions: []action.Action{
+			action.Set([]byte("k1"), []byte("v1")),
+			action.Set([]byte("k2"), []byte("v2")),
+			action.Set([]byte("l1"), []byte("vl1")),
+			action.Watch(nil),
+			action.DeletePrefix([]byte("k")),
+			action.Events(
+				0,
+				corekv.Event{Key: []byte("k1"), IsDeleted: true},
+				corekv.Event{Key: []byte("k2"), IsDeleted: true},
+			),
+			action.Has([]byte("l1"), true),
+		},
+	}
+
+	test.Execute(t)
+}
+
+func TestWatch_SetImmediatelyAfterWatch(t *testing.T) {
+	actions := []action.Action{}
+	for i := 0; i < 10; i++ {
+		key := []byte(fmt.Sprintf("k%v", i))
+		actions = append(
+			actions,
+			action.Watch(key),
+			action.Set(key, []byte("v")),
+			action.Events(i, corekv.Event{Key: key, Value: []byte("v")}),
+		)
+	}
+
+	test := &Test{
+		Actions: actions,
+	}
+
+	test.Execute(t)
+}
+
+func TestWatch_NilValue(t *testing.T) {
+	test := &Test{
+		Actions: []action.Action{
+			action.Watch(nil),
+			action.Set([]byte("k1"), nil),
+			action.Delete([]byte("k1")),
+			action.Events(
+				0,
+				corekv.Event{Key: []byte("k1"), Value: nil},
+				corekv.Event{Key: []byte("k1"), IsDeleted: true},
+			),
+		},
+	}
+
+	test.Execute(t)
+}
+
+func TestWatch_TxnCommit(t *testing.T) {
+	test := &Test{
+		Actions: []action.Action{
+			action.Watch(nil),
+			action.NewTxn(false),
+			action.WithTxn(0, action.Set([]byte("k2"), []byte("v2"))),
+			action.WithTxn(0, action.Set([]byte("k1"), []byte("v1"))),
+			action.Commit(0),
+			action.Set([]byte("k3"), []byte("v3")),
+			action.Events(
+				0,
+				corekv.Event{Key: []byte("k1"), Value: []byte("v1")},
+				corekv.Event{Key: []byte("k2"), Value: []byte("v2")},
+				corekv.Event{Key: []byte("k3"), Value: []byte("v3")},
+			),
+		},
+	}
+
+	test.Execute(t)
+}
+
+func TestWatch_Multiple(t *testing.T) {
+	test := &Test{
+		Actions: []action.Action{
+			action.Watch([]byte("k1")),
+			action.Watch([]byte("k2")),
+			action.Set([]byte("k1"), []byte("v1")),
+			action.Set([]byte("k2"), []byte("v2")),
+			action.Events(
+				1,
+				corekv.Event{Key: []byte("k2"), Value: []byte("v2")},
+			),
+			action.Events(
+				0,
+				corekv.Event{Key: []byte("k1"), Value: []byte("v1")},
+			),
+		},
+	}
+
+	test.Execute(t)
+}
+
+func TestWatch_Overflow(t *testing.T) {
+	actions := []action.Action{
+		action.Watch(nil),
+	}
+	// The events are not received whilst the changes are committed, so the watch's queue
+	// of the default size will overflow.
+	for i := 0; i < 2000; i++ {
+		actions = append(actions, action.Set([]byte(fmt.Sprintf("k%v", i)), []byte("v")))
+	}
+	actions = append(actions, action.Overflowed(0))
+
+	test := &Test{
+		Actions: actions,
+	}
+
+	test.Execute(t)
+}
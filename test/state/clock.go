@@ -0,0 +1,34 @@
+package state
+
+import (
+	"sync"
+	"time"
+)
+
+// Clock is a manually advanced clock, allowing time dependent behaviour to be tested
+// deterministically.
+type Clock struct {
+	lk  sync.Mutex
+	now time.Time
+}
+
+// NewClock returns a new [*Clock] set to the given time.
+func NewClock(now time.Time) *Clock {
+	return &Clock{
+		now: now,
+	}
+}
+
+// Now returns the current time of the clock.
+func (c *Clock) Now() time.Time {
+	c.lk.Lock()
+	defer c.lk.Unlock()
+	return c.now
+}
+
+// Advance moves the clock forward by the given duration.
+func (c *Clock) Advance(d time.Duration) {
+	c.lk.Lock()
+	defer c.lk.Unlock()
+	c.now = c.now.Add(d)
+}
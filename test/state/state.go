@@ -21,9 +21,24 @@ type State struct {
 	// The root [corekv.Store] containing all store state within this execution.
 	Rootstore corekv.Store
 
+	// The clock of the Rootstore, nil if the Rootstore reads the system clock.
+	Clock *Clock
+
 	// The active [corekv.Store] layer, through which actions should interact with.
 	//
 	// This must be derived from the Rootstore.  For example it may be a namespace within the
 	// Rootstore, or even a transaction of that namespace.
 	Store corekv.Store
+
+	// The transactions created during this execution, indexed by the order in which
+	// they were created.
+	Txns []corekv.Txn
+
+	// The batches created during this execution, indexed by the order in which
+	// they were created.
+	Batches []corekv.Batch
+
+	// The event channels of the watches created during this execution, indexed by the
+	// order in which they were created.
+	Watches []<-chan corekv.Event
 }
@@ -0,0 +1,87 @@
+package tracing
+
+import (
+	"context"
+
+	"github.com/sourcenetwork/corekv"
+)
+
+var _ corekv.Batchable = (*tracedStore)(nil)
+
+// Batch returns a new batch of the underlying store, spans are created for the operations
+// made against the batch in the same way as those made against a transaction.
+//
+// If the underlying store does not support batches [corekv.ErrNotSupported] will be returned.
+func (s *tracedStore) Batch(ctx context.Context) (corekv.Batch, error) {
+	store, ok := s.store.(corekv.Batchable)
+	if !ok {
+		return nil, corekv.ErrNotSupported
+	}
+
+	ctx, span := s.start(ctx, SpanBatch)
+	defer span.End()
+
+	batch, err := store.Batch(ctx)
+	recordError(span, err)
+	if err != nil {
+		return nil, err
+	}
+
+	return &tracedBatch{
+		batch: batch,
+		parent: &tracedRW{
+			tracer:          s.tracer,
+			keyPrefixLength: s.keyPrefixLength,
+			attrs:           []Attribute{Bool(AttrBatch, true)},
+		},
+	}, nil
+}
+
+type tracedBatch struct {
+	batch corekv.Batch
+
+	// The read-writer used to create the spans of this batch, it does not hold a store.
+	parent *tracedRW
+}
+
+var _ corekv.Batch = (*tracedBatch)(nil)
+
+func (b *tracedBatch) Set(ctx context.Context, key []byte, value []byte) error {
+	ctx, span := b.parent.start(ctx, SpanSet, b.parent.keyPrefix(key))
+	defer span.End()
+
+	err := b.batch.Set(ctx, key, value)
+	recordError(span, err)
+
+	return err
+}
+
+func (b *tracedBatch) Delete(ctx context.Context, key []byte) error {
+	ctx, span := b.parent.start(ctx, SpanDelete, b.parent.keyPrefix(key))
+	defer span.End()
+
+	err := b.batch.Delete(ctx, key)
+	recordError(span, err)
+
+	return err
+}
+
+func (b *tracedBatch) Commit(ctx context.Context) error {
+	ctx, span := b.parent.start(ctx, SpanCommit)
+	defer span.End()
+
+	err := b.batch.Commit(ctx)
+	recordError(span, err)
+
+	return err
+}
+
+func (b *tracedBatch) Discard(ctx context.Context) error {
+	ctx, span := b.parent.start(ctx, SpanDiscard)
+	defer span.End()
+
+	err := b.batch.Discard(ctx)
+	recordError(span, err)
+
+	return err
+}
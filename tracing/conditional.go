@@ -0,0 +1,69 @@
+package tracing
+
+import (
+	"context"
+
+	"github.com/sourcenetwork/corekv"
+)
+
+var _ corekv.ConditionalWriter = (*tracedStore)(nil)
+
+// CompareAndSwap sets the value stored against the given key to newValue, if the currently
+// stored value is equal to expectedOld.
+//
+// If the underlying store does not support conditional writes [corekv.ErrNotSupported] will
+// be returned.
+func (s *tracedStore) CompareAndSwap(ctx context.Context, key, expectedOld, newValue []byte) (bool, error) {
+	store, ok := s.store.(corekv.ConditionalWriter)
+	if !ok {
+		return false, corekv.ErrNotSupported
+	}
+
+	ctx, span := s.start(ctx, SpanCompareAndSwap, s.keyPrefix(key))
+	defer span.End()
+
+	swapped, err := store.CompareAndSwap(ctx, key, expectedOld, newValue)
+	recordError(span, err)
+
+	return swapped, err
+}
+
+// SetIfAbsent sets the value stored against the given key, if no item currently exists at
+// that key.
+//
+// If the underlying store does not support conditional writes [corekv.ErrNotSupported] will
+// be returned.
+func (s *tracedStore) SetIfAbsent(ctx context.Context, key, value []byte) (bool, error) {
+	store, ok := s.store.(corekv.ConditionalWriter)
+	if !ok {
+		return false, corekv.ErrNotSupported
+	}
+
+	ctx, span := s.start(ctx, SpanSetIfAbsent, s.keyPrefix(key))
+	defer span.End()
+
+	set, err := store.SetIfAbsent(ctx, key, value)
+	recordError(span, err)
+
+	return set, err
+}
+
+// DeleteIfEquals removes the item at the given key, if the currently stored value is equal
+// to expected.
+//
+// If the underlying store does not support conditional writes [corekv.ErrNotSupported] will
+// be returned.
+func (s *tracedStore) DeleteIfEquals(ctx context.Context, key, expected []byte) (bool, error) {
+	store, ok := s.store.(corekv.ConditionalWriter)
+	if !ok {
+		return false, corekv.ErrNotSupported
+	}
+
+	ctx, span := s.start(ctx, SpanDeleteIfEquals, s.keyPrefix(key))
+	defer span.End()
+
+	deleted, err := store.DeleteIfEquals(ctx, key, expected)
+	recordError(span, err)
+
+	return deleted, err
+}
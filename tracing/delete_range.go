@@ -0,0 +1,53 @@
+package tracing
+
+import (
+	"context"
+
+	"github.com/sourcenetwork/corekv"
+)
+
+var _ corekv.RangeDeleter = (*tracedStore)(nil)
+
+// DeleteRange removes all items within the given range from the underlying store.
+//
+// If the underlying store does not support deleting ranges [corekv.ErrNotSupported] will be
+// returned.
+func (s *tracedStore) DeleteRange(ctx context.Context, start, end []byte) error {
+	store, ok := s.store.(corekv.RangeDeleter)
+	if !ok {
+		return corekv.ErrNotSupported
+	}
+
+	ctx, span := s.start(
+		ctx,
+		SpanDeleteRange,
+		String(AttrRangeStart, string(s.truncate(start))),
+		String(AttrRangeEnd, string(s.truncate(end))),
+	)
+	defer span.End()
+
+	err := store.DeleteRange(ctx, start, end)
+	recordError(span, err)
+
+	return err
+}
+
+// DeletePrefix removes all items with a key beginning with the given prefix from the
+// underlying store.
+//
+// If the underlying store does not support deleting ranges [corekv.ErrNotSupported] will be
+// returned.
+func (s *tracedStore) DeletePrefix(ctx context.Context, prefix []byte) error {
+	store, ok := s.store.(corekv.RangeDeleter)
+	if !ok {
+		return corekv.ErrNotSupported
+	}
+
+	ctx, span := s.start(ctx, SpanDeletePrefix, s.keyPrefix(prefix))
+	defer span.End()
+
+	err := store.DeletePrefix(ctx, prefix)
+	recordError(span, err)
+
+	return err
+}
@@ -0,0 +1,52 @@
+package tracing
+
+import (
+	"context"
+
+	"github.com/sourcenetwork/corekv"
+)
+
+var (
+	_ corekv.MultiReader = (*tracedStore)(nil)
+	_ corekv.MultiReader = (*tracedTxn)(nil)
+)
+
+// GetMany returns the values at the given keys, within a single span recording the number of
+// keys given.
+//
+// If the underlying store does not support reading many items [corekv.ErrNotSupported] will
+// be returned.
+func (s *tracedRW) GetMany(ctx context.Context, keys [][]byte) ([]corekv.GetResult, error) {
+	rw, ok := s.rw.(corekv.MultiReader)
+	if !ok {
+		return nil, corekv.ErrNotSupported
+	}
+
+	ctx, span := s.start(ctx, SpanGetMany, Int(AttrKeyCount, len(keys)))
+	defer span.End()
+
+	results, err := rw.GetMany(ctx, keys)
+	recordError(span, err)
+
+	return results, err
+}
+
+// HasMany returns true for each of the given keys at which an item is found, within a single
+// span recording the number of keys given.
+//
+// If the underlying store does not support reading many items [corekv.ErrNotSupported] will
+// be returned.
+func (s *tracedRW) HasMany(ctx context.Context, keys [][]byte) ([]bool, error) {
+	rw, ok := s.rw.(corekv.MultiReader)
+	if !ok {
+		return nil, corekv.ErrNotSupported
+	}
+
+	ctx, span := s.start(ctx, SpanHasMany, Int(AttrKeyCount, len(keys)))
+	defer span.End()
+
+	results, err := rw.HasMany(ctx, keys)
+	recordError(span, err)
+
+	return results, err
+}
@@ -0,0 +1,112 @@
+package tracing
+
+import (
+	"context"
+
+	"github.com/sourcenetwork/corekv"
+)
+
+// iterator holds a span for the lifetime of an iterator, creating child spans for each
+// call to Next, Seek, Prev and Peek.
+type iterator struct {
+	it corekv.Iterator
+
+	// The context holding the span of this iterator.
+	ctx  context.Context
+	span Span
+
+	parent *tracedRW
+}
+
+var _ corekv.BidirectionalIterator = (*iterator)(nil)
+
+func (iter *iterator) Reset() {
+	iter.it.Reset()
+}
+
+func (iter *iterator) Next() (bool, error) {
+	_, span := iter.parent.start(iter.ctx, SpanIteratorNext)
+	defer span.End()
+
+	hasNext, err := iter.it.Next()
+	iter.annotate(span, hasNext, err)
+
+	return hasNext, err
+}
+
+func (iter *iterator) Key() []byte {
+	return iter.it.Key()
+}
+
+func (iter *iterator) Value() ([]byte, error) {
+	return iter.it.Value()
+}
+
+func (iter *iterator) Seek(key []byte) (bool, error) {
+	_, span := iter.parent.start(iter.ctx, SpanIteratorSeek)
+	defer span.End()
+
+	hasNext, err := iter.it.Seek(key)
+	iter.annotate(span, hasNext, err)
+
+	return hasNext, err
+}
+
+// Prev moves the iterator backwards.
+//
+// If the underlying iterator is not bidirectional [corekv.ErrNotSupported] will be returned.
+func (iter *iterator) Prev() (bool, error) {
+	it, ok := iter.it.(corekv.BidirectionalIterator)
+	if !ok {
+		return false, corekv.ErrNotSupported
+	}
+
+	_, span := iter.parent.start(iter.ctx, SpanIteratorPrev)
+	defer span.End()
+
+	hasPrev, err := it.Prev()
+	iter.annotate(span, hasPrev, err)
+
+	return hasPrev, err
+}
+
+// Peek returns the key of the item that would be yielded by the next `Next` call.
+//
+// If the underlying iterator is not bidirectional [corekv.ErrNotSupported] will be returned.
+func (iter *iterator) Peek() ([]byte, bool, error) {
+	it, ok := iter.it.(corekv.BidirectionalIterator)
+	if !ok {
+		return nil, false, corekv.ErrNotSupported
+	}
+
+	_, span := iter.parent.start(iter.ctx, SpanIteratorPeek)
+	defer span.End()
+
+	key, hasNext, err := it.Peek()
+	span.SetAttributes(Bool(AttrHasNext, hasNext))
+	if hasNext {
+		span.SetAttributes(iter.parent.keyPrefix(key))
+	}
+	recordError(span, err)
+
+	return key, hasNext, err
+}
+
+// Close closes the iterator, ending its span.
+func (iter *iterator) Close(ctx context.Context) error {
+	defer iter.span.End()
+
+	err := iter.it.Close(ctx)
+	recordError(iter.span, err)
+
+	return err
+}
+
+// annotate sets the result of moving the iterator on the given span.
+func (iter *iterator) annotate(span Span, hasNext bool, err error) {
+	span.SetAttributes(Bool(AttrHasNext, hasNext))
+	if hasNext {
+		span.SetAttributes(iter.parent.keyPrefix(iter.it.Key()))
+	}
+	recordError(span, err)
+}
@@ -0,0 +1,122 @@
+package tracing
+
+import (
+	"context"
+	"sync"
+)
+
+// RecordedSpan is a span created by a [MemoryTracer].
+type RecordedSpan struct {
+	// The name of the span.
+	Name string
+
+	// The index of the parent of this span, or -1 if it has no parent.
+	Parent int
+
+	// The attributes set on the span, in the order in which they were first set.
+	Attributes []Attribute
+
+	// The errors recorded against the span.
+	Errors []error
+
+	// Whether the span has ended.
+	Ended bool
+}
+
+// MemoryTracer is a [Tracer] that holds the spans it creates in memory.
+type MemoryTracer struct {
+	lk    sync.Mutex
+	spans []RecordedSpan
+}
+
+var _ Tracer = (*MemoryTracer)(nil)
+
+// NewMemoryTracer returns a new, empty, [*MemoryTracer].
+func NewMemoryTracer() *MemoryTracer {
+	return &MemoryTracer{}
+}
+
+// spanKey is the context key of the span held by a context.
+type spanKey struct{}
+
+func (t *MemoryTracer) Start(ctx context.Context, name string, attrs ...Attribute) (context.Context, Span) {
+	t.lk.Lock()
+	defer t.lk.Unlock()
+
+	parent := -1
+	if s, ok := ctx.Value(spanKey{}).(*memorySpan); ok && s.tracer == t {
+		parent = s.index
+	}
+
+	t.spans = append(t.spans, RecordedSpan{
+		Name:   name,
+		Parent: parent,
+	})
+
+	span := &memorySpan{
+		tracer: t,
+		index:  len(t.spans) - 1,
+	}
+	span.setAttributes(attrs)
+
+	return context.WithValue(ctx, spanKey{}, span), span
+}
+
+// Spans returns the spans created by this tracer, in the order in which they were created.
+func (t *MemoryTracer) Spans() []RecordedSpan {
+	t.lk.Lock()
+	defer t.lk.Unlock()
+
+	spans := make([]RecordedSpan, len(t.spans))
+	for i, span := range t.spans {
+		span.Attributes = append([]Attribute(nil), span.Attributes...)
+		span.Errors = append([]error(nil), span.Errors...)
+		spans[i] = span
+	}
+
+	return spans
+}
+
+type memorySpan struct {
+	tracer *MemoryTracer
+	index  int
+}
+
+var _ Span = (*memorySpan)(nil)
+
+func (s *memorySpan) SetAttributes(attrs ...Attribute) {
+	s.tracer.lk.Lock()
+	defer s.tracer.lk.Unlock()
+
+	s.setAttributes(attrs)
+}
+
+func (s *memorySpan) setAttributes(attrs []Attribute) {
+	recorded := &s.tracer.spans[s.index]
+
+attrs:
+	for _, attr := range attrs {
+		for i := range recorded.Attributes {
+			if recorded.Attributes[i].Key == attr.Key {
+				recorded.Attributes[i].Value = attr.Value
+				continue attrs
+			}
+		}
+		recorded.Attributes = append(recorded.Attributes, attr)
+	}
+}
+
+func (s *memorySpan) RecordError(err error) {
+	s.tracer.lk.Lock()
+	defer s.tracer.lk.Unlock()
+
+	recorded := &s.tracer.spans[s.index]
+	recorded.Errors = append(recorded.Errors, err)
+}
+
+func (s *memorySpan) End() {
+	s.tracer.lk.Lock()
+	defer s.tracer.lk.Unlock()
+
+	s.tracer.spans[s.index].Ended = true
+}
@@ -0,0 +1,204 @@
+package tracing
+
+import (
+	"context"
+
+	"github.com/sourcenetwork/corekv"
+)
+
+// readWriter is the set of functions shared by stores and transactions.
+type readWriter interface {
+	corekv.Reader
+	corekv.Writer
+}
+
+// tracedRW creates spans for the reads and writes made against a store or transaction.
+type tracedRW struct {
+	rw     readWriter
+	tracer Tracer
+
+	// The number of leading key bytes recorded against spans.
+	keyPrefixLength int
+
+	// Attributes given to every span created by this read-writer.
+	attrs []Attribute
+}
+
+type tracedStore struct {
+	tracedRW
+	store corekv.Store
+}
+
+var _ Traced = (*tracedStore)(nil)
+
+// Wrap returns a store that creates spans, using the given tracer, for the operations made
+// against the given store.
+//
+// Up to keyPrefixLength leading bytes of the keys given to each operation are recorded
+// against its span, if zero [DefaultKeyPrefixLength] is used.
+//
+// If the given store is a [corekv.TxnStore] the returned store will also be a
+// [corekv.TxnStore], with spans also created for operations made against its transactions.
+func Wrap(store corekv.Store, tracer Tracer, keyPrefixLength int) Traced {
+	if keyPrefixLength == 0 {
+		keyPrefixLength = DefaultKeyPrefixLength
+	}
+
+	tstore := &tracedStore{
+		tracedRW: tracedRW{
+			rw:              store,
+			tracer:          tracer,
+			keyPrefixLength: keyPrefixLength,
+		},
+		store: store,
+	}
+
+	if _, ok := store.(corekv.TxnStore); ok {
+		return &tracedTxnStore{tstore}
+	}
+
+	return tstore
+}
+
+func (s *tracedRW) Get(ctx context.Context, key []byte) ([]byte, error) {
+	ctx, span := s.start(ctx, SpanGet, s.keyPrefix(key))
+	defer span.End()
+
+	value, err := s.rw.Get(ctx, key)
+	recordError(span, err)
+
+	return value, err
+}
+
+func (s *tracedRW) Has(ctx context.Context, key []byte) (bool, error) {
+	ctx, span := s.start(ctx, SpanHas, s.keyPrefix(key))
+	defer span.End()
+
+	has, err := s.rw.Has(ctx, key)
+	recordError(span, err)
+
+	return has, err
+}
+
+func (s *tracedRW) Set(ctx context.Context, key []byte, value []byte) error {
+	ctx, span := s.start(ctx, SpanSet, s.keyPrefix(key))
+	defer span.End()
+
+	err := s.rw.Set(ctx, key, value)
+	recordError(span, err)
+
+	return err
+}
+
+func (s *tracedRW) Delete(ctx context.Context, key []byte) error {
+	ctx, span := s.start(ctx, SpanDelete, s.keyPrefix(key))
+	defer span.End()
+
+	err := s.rw.Delete(ctx, key)
+	recordError(span, err)
+
+	return err
+}
+
+// Iterator returns an iterator whose span lasts until it is closed, with a child span
+// created for each call to Next, Seek, Prev and Peek.
+func (s *tracedRW) Iterator(ctx context.Context, opts corekv.IterOptions) corekv.Iterator {
+	ctx, span := s.start(
+		ctx,
+		SpanIterator,
+		String(AttrIterPrefix, string(s.truncate(opts.Prefix))),
+		Bool(AttrIterReverse, opts.Reverse),
+	)
+
+	return &iterator{
+		it:     s.rw.Iterator(ctx, opts),
+		ctx:    ctx,
+		span:   span,
+		parent: s,
+	}
+}
+
+func (s *tracedRW) Tracer() Tracer {
+	return s.tracer
+}
+
+func (s *tracedStore) Close() error {
+	_, span := s.start(context.Background(), SpanClose)
+	defer span.End()
+
+	err := s.store.Close()
+	recordError(span, err)
+
+	return err
+}
+
+type tracedTxnStore struct {
+	*tracedStore
+}
+
+var _ corekv.TxnStore = (*tracedTxnStore)(nil)
+
+func (s *tracedTxnStore) NewTxn(readonly bool) corekv.Txn {
+	return &tracedTxn{
+		tracedRW: tracedRW{
+			rw:              s.store.(corekv.TxnStore).NewTxn(readonly),
+			tracer:          s.tracer,
+			keyPrefixLength: s.keyPrefixLength,
+			attrs: []Attribute{
+				Bool(AttrTxn, true),
+				Bool(AttrTxnReadOnly, readonly),
+			},
+		},
+	}
+}
+
+type tracedTxn struct {
+	tracedRW
+}
+
+var _ corekv.Txn = (*tracedTxn)(nil)
+
+func (t *tracedTxn) Commit(ctx context.Context) error {
+	ctx, span := t.start(ctx, SpanCommit)
+	defer span.End()
+
+	err := t.rw.(corekv.Txn).Commit(ctx)
+	recordError(span, err)
+
+	return err
+}
+
+func (t *tracedTxn) Discard(ctx context.Context) error {
+	ctx, span := t.start(ctx, SpanDiscard)
+	defer span.End()
+
+	err := t.rw.(corekv.Txn).Discard(ctx)
+	recordError(span, err)
+
+	return err
+}
+
+// start creates a new span with the given attributes, along with those common to all spans
+// created by this read-writer.
+func (s *tracedRW) start(ctx context.Context, name string, attrs ...Attribute) (context.Context, Span) {
+	return s.tracer.Start(ctx, name, append(attrs, s.attrs...)...)
+}
+
+// keyPrefix returns the key prefix attribute for the given key.
+func (s *tracedRW) keyPrefix(key []byte) Attribute {
+	return String(AttrKeyPrefix, string(s.truncate(key)))
+}
+
+func (s *tracedRW) truncate(key []byte) []byte {
+	if len(key) > s.keyPrefixLength {
+		return key[:s.keyPrefixLength]
+	}
+	return key
+}
+
+// recordError records the given error against the given span, if it is not nil.
+func recordError(span Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+	}
+}
@@ -0,0 +1,125 @@
+package tracing
+
+import (
+	"context"
+
+	"github.com/sourcenetwork/corekv"
+)
+
+// Span names given to the spans created by traced stores.
+const (
+	SpanGet          = "corekv.Get"
+	SpanHas          = "corekv.Has"
+	SpanSet          = "corekv.Set"
+	SpanDelete       = "corekv.Delete"
+	SpanIterator     = "corekv.Iterator"
+	SpanIteratorNext = "corekv.Iterator.Next"
+	SpanIteratorSeek = "corekv.Iterator.Seek"
+	SpanIteratorPrev = "corekv.Iterator.Prev"
+	SpanIteratorPeek = "corekv.Iterator.Peek"
+	SpanCommit       = "corekv.Commit"
+	SpanDiscard      = "corekv.Discard"
+	SpanClose        = "corekv.Close"
+
+	SpanGetMany        = "corekv.GetMany"
+	SpanHasMany        = "corekv.HasMany"
+	SpanSetWithTTL     = "corekv.SetWithTTL"
+	SpanDeleteRange    = "corekv.DeleteRange"
+	SpanDeletePrefix   = "corekv.DeletePrefix"
+	SpanCompareAndSwap = "corekv.CompareAndSwap"
+	SpanSetIfAbsent    = "corekv.SetIfAbsent"
+	SpanDeleteIfEquals = "corekv.DeleteIfEquals"
+	SpanBatch          = "corekv.Batch"
+	SpanWatch          = "corekv.Watch"
+)
+
+// Attribute keys given to the spans created by traced stores.
+const (
+	// AttrKeyPrefix holds the leading bytes of the key given to, or yielded by, an operation.
+	AttrKeyPrefix = "corekv.key_prefix"
+
+	// AttrIterPrefix holds the prefix option given to an iterator.
+	AttrIterPrefix = "corekv.iterator.prefix"
+
+	// AttrIterReverse holds whether an iterator iterates in reverse.
+	AttrIterReverse = "corekv.iterator.reverse"
+
+	// AttrHasNext holds whether a call to Next, Seek or Prev positioned the iterator on an item,
+	// or whether a call to Peek found a next item.
+	AttrHasNext = "corekv.iterator.has_next"
+
+	// AttrTxn holds whether an operation was made against a transaction.
+	AttrTxn = "corekv.txn"
+
+	// AttrTxnReadOnly holds whether the transaction an operation was made against is read only.
+	AttrTxnReadOnly = "corekv.txn.readonly"
+
+	// AttrBatch holds whether an operation was made against a batch.
+	AttrBatch = "corekv.batch"
+
+	// AttrKeyCount holds the number of keys given to an operation reading many items.
+	AttrKeyCount = "corekv.key_count"
+
+	// AttrRangeStart holds the leading bytes of the start of the range given to DeleteRange.
+	AttrRangeStart = "corekv.range.start"
+
+	// AttrRangeEnd holds the leading bytes of the end of the range given to DeleteRange.
+	AttrRangeEnd = "corekv.range.end"
+)
+
+// DefaultKeyPrefixLength is the number of leading key bytes recorded against spans if
+// no other length is given.
+const DefaultKeyPrefixLength = 16
+
+// Attribute is a key-value pair describing a span.
+type Attribute struct {
+	Key   string
+	Value any
+}
+
+// String returns a new string [Attribute].
+func String(key string, value string) Attribute {
+	return Attribute{Key: key, Value: value}
+}
+
+// Bool returns a new bool [Attribute].
+func Bool(key string, value bool) Attribute {
+	return Attribute{Key: key, Value: value}
+}
+
+// Int returns a new int [Attribute].
+func Int(key string, value int) Attribute {
+	return Attribute{Key: key, Value: value}
+}
+
+// Tracer creates spans.
+//
+// It mirrors the shape of the OpenTelemetry tracer so that an OpenTelemetry (or any other)
+// tracer can be adapted to it with little code.
+type Tracer interface {
+	// Start creates a new span, the child of any span held by the given context.
+	//
+	// The returned context holds the new span.
+	Start(ctx context.Context, name string, attrs ...Attribute) (context.Context, Span)
+}
+
+// Span is a single, timed, operation within a trace.
+type Span interface {
+	// SetAttributes sets the given attributes on the span, overwriting any existing
+	// attributes of the same key.
+	SetAttributes(attrs ...Attribute)
+
+	// RecordError records the given error against the span.
+	RecordError(err error)
+
+	// End completes the span.
+	End()
+}
+
+// Traced is a [corekv.Store] that creates spans for the operations made against it.
+type Traced interface {
+	corekv.Store
+
+	// Tracer returns the tracer that spans are created by.
+	Tracer() Tracer
+}
@@ -0,0 +1,33 @@
+package tracing
+
+import (
+	"context"
+	"time"
+
+	"github.com/sourcenetwork/corekv"
+)
+
+var (
+	_ corekv.TTLWriter = (*tracedStore)(nil)
+	_ corekv.TTLWriter = (*tracedTxn)(nil)
+)
+
+// SetWithTTL sets the value stored against the given key, expiring it once the given
+// time-to-live has passed.
+//
+// If the underlying store does not support expiring items [corekv.ErrNotSupported] will be
+// returned.
+func (s *tracedRW) SetWithTTL(ctx context.Context, key, value []byte, ttl time.Duration) error {
+	rw, ok := s.rw.(corekv.TTLWriter)
+	if !ok {
+		return corekv.ErrNotSupported
+	}
+
+	ctx, span := s.start(ctx, SpanSetWithTTL, s.keyPrefix(key))
+	defer span.End()
+
+	err := rw.SetWithTTL(ctx, key, value, ttl)
+	recordError(span, err)
+
+	return err
+}
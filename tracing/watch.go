@@ -0,0 +1,28 @@
+package tracing
+
+import (
+	"context"
+
+	"github.com/sourcenetwork/corekv"
+)
+
+var _ corekv.Watchable = (*tracedStore)(nil)
+
+// Watch returns a channel yielding the changes committed to items with the given prefix in
+// the underlying store, within a span covering the call to begin watching.
+//
+// If the underlying store does not support watching [corekv.ErrNotSupported] will be returned.
+func (s *tracedStore) Watch(ctx context.Context, prefix []byte) (<-chan corekv.Event, error) {
+	store, ok := s.store.(corekv.Watchable)
+	if !ok {
+		return nil, corekv.ErrNotSupported
+	}
+
+	ctx, span := s.start(ctx, SpanWatch, s.keyPrefix(prefix))
+	defer span.End()
+
+	events, err := store.Watch(ctx, prefix)
+	recordError(span, err)
+
+	return events, err
+}